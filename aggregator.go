@@ -0,0 +1,103 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// TaggedEntry is an entry collected by a LogAggregator, tagged with the name
+// of the logger it was logged with.
+type TaggedEntry struct {
+	LogEntry
+	// Source is the name the logger was attached with.
+	Source string
+}
+
+// LogAggregator collects the entries of several loggers into a single
+// stream, like to assert on the logs of all the subsystems of a test suite.
+// The loggers keep writing to their own output. The zero value is an empty
+// aggregator, ready to use.
+type LogAggregator struct {
+	mu      sync.Mutex
+	entries []TaggedEntry
+	stops   map[string]func()
+}
+
+// Attach starts collecting the entries of l, and of the loggers derived from
+// it afterwards, tagged with name. All the log calls are collected,
+// whatever the level of l, with their timestamp and call site, and the
+// prefix l has when it's attached. Attaching another logger under name
+// detaches the previous one.
+func (a *LogAggregator) Attach(name string, l *Logger) {
+	l.mu.RLock()
+	prefix, callerFormatter := l.prefix, l.callerFormatter
+	l.mu.RUnlock()
+
+	dst := NewWithOptions(&aggregatorWriter{a: a, source: name}, Options{
+		Level:           DebugLevel,
+		Prefix:          prefix,
+		ReportTimestamp: true,
+		ReportCaller:    true,
+		TimeFormat:      time.RFC3339Nano,
+		Formatter:       JSONFormatter,
+		CallerFormatter: callerFormatter,
+	})
+	stop := l.ExportTo(dst)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if prev := a.stops[name]; prev != nil {
+		prev()
+	}
+	if a.stops == nil {
+		a.stops = make(map[string]func())
+	}
+	a.stops[name] = stop
+}
+
+// Detach stops collecting the entries of the logger attached under name. The
+// entries already collected are kept.
+func (a *LogAggregator) Detach(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if stop := a.stops[name]; stop != nil {
+		stop()
+		delete(a.stops, name)
+	}
+}
+
+// Entries returns the collected entries at minLevel or above, in the order
+// they were logged. Entries without a level, logged with Print, are always
+// returned.
+func (a *LogAggregator) Entries(minLevel Level) []TaggedEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entries := make([]TaggedEntry, 0, len(a.entries))
+	for _, e := range a.entries {
+		if e.Level >= minLevel {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// aggregatorWriter parses the entries written to it, and collects them into
+// a, tagged with source.
+type aggregatorWriter struct {
+	a      *LogAggregator
+	source string
+}
+
+// Write implements io.Writer.
+func (w *aggregatorWriter) Write(p []byte) (int, error) {
+	entries, err := parseOutput(p)
+	if err != nil {
+		return 0, err
+	}
+	w.a.mu.Lock()
+	defer w.a.mu.Unlock()
+	for _, e := range entries {
+		w.a.entries = append(w.a.entries, TaggedEntry{LogEntry: e, Source: w.source})
+	}
+	return len(p), nil
+}
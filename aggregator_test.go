@@ -0,0 +1,86 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogAggregator(t *testing.T) {
+	var dbOut, httpOut bytes.Buffer
+	db := New(&dbOut).WithPrefix("db")
+	http := New(&httpOut)
+	http.SetLevel(WarnLevel)
+
+	var a LogAggregator
+	a.Attach("db", db)
+	a.Attach("http", http)
+
+	_, _, line, _ := runtime.Caller(0)
+	db.Info("connected", "pool", 4)
+	http.With("path", "/").Debug("request")
+	db.Error("query failed", "table", "users")
+	http.Print("listening")
+
+	require.Equal(t, "INFO db: connected pool=4\nERRO db: query failed table=users\n", dbOut.String())
+	require.Equal(t, "listening\n", httpOut.String())
+
+	entries := a.Entries(DebugLevel)
+	require.Len(t, entries, 4)
+	require.Equal(t, "db", entries[0].Source)
+	require.Equal(t, InfoLevel, entries[0].Level)
+	require.Equal(t, "db", entries[0].Prefix)
+	require.Equal(t, "connected", entries[0].Message)
+	require.Equal(t, []interface{}{"pool", int64(4)}, entries[0].Fields)
+	require.Equal(t, fmt.Sprintf("log/aggregator_test.go:%d", line+1), entries[0].Caller)
+	require.False(t, entries[0].Time.IsZero())
+
+	require.Equal(t, "http", entries[1].Source)
+	require.Equal(t, DebugLevel, entries[1].Level)
+	require.Equal(t, []interface{}{"path", "/"}, entries[1].Fields)
+
+	var got []string
+	for _, e := range a.Entries(ErrorLevel) {
+		got = append(got, e.Source+" "+e.Message)
+	}
+	require.Equal(t, []string{"db query failed", "http listening"}, got)
+}
+
+func TestLogAggregator_detach(t *testing.T) {
+	var a LogAggregator
+	l := New(ioutil.Discard)
+	a.Attach("app", l)
+	l.Info("one")
+	a.Detach("app")
+	a.Detach("unknown")
+	l.Info("two")
+
+	other := New(ioutil.Discard)
+	a.Attach("app", other)
+	a.Attach("app", New(ioutil.Discard))
+	other.Info("three")
+
+	entries := a.Entries(InfoLevel)
+	require.Len(t, entries, 1)
+	require.Equal(t, "one", entries[0].Message)
+}
+
+func TestLogAggregator_filtered(t *testing.T) {
+	var out bytes.Buffer
+	l := New(&out)
+	l.SetLevel(WarnLevel)
+
+	var a LogAggregator
+	a.Attach("app", l)
+	l.Debugf("retry %d", 2)
+
+	require.Empty(t, out.String())
+	entries := a.Entries(DebugLevel)
+	require.Len(t, entries, 1)
+	require.Equal(t, DebugLevel, entries[0].Level)
+	require.Equal(t, "retry 2", entries[0].Message)
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+)
+
+// SignatureKey is the key of the field holding the signature of the entries
+// of audit loggers.
+var SignatureKey = "sig"
+
+// NewAuditLogger returns a new logger, derived from l, that signs its entries
+// so that they can be checked for tampering later, with Verify. Each entry is
+// formatted as usual, then the HMAC-SHA256 of the formatted entry, keyed with
+// key, is appended to it as a hex-encoded "sig" field. It's appended as a
+// text field, like "sig=<hmac>", or as a JSON key with the JSON formatter.
+// Loggers derived from the returned logger sign their entries too.
+func NewAuditLogger(l *Logger, key []byte) *Logger {
+	l.mu.RLock()
+	aw := &auditWriter{w: l.w, key: key}
+	l.mu.RUnlock()
+
+	sl := l.With()
+	sl.w = aw
+	return sl
+}
+
+// Verify returns true if line is an entry written by an audit logger, with or
+// without its trailing newline, and its signature matches its content and
+// key.
+func Verify(line string, key []byte) bool {
+	line = strings.TrimSuffix(line, "\n")
+	textSig := " " + SignatureKey + "="
+	jsonSig := `"` + SignatureKey + `":"`
+
+	var body, sig string
+	switch {
+	case strings.HasSuffix(line, `"}`) && strings.HasPrefix(line, "{"):
+		i := strings.LastIndex(line, jsonSig)
+		if i < 1 {
+			return false
+		}
+		sig = line[i+len(jsonSig) : len(line)-2]
+		switch line[i-1] {
+		case ',':
+			body = line[:i-1] + "}"
+		case '{':
+			body = "{}"
+		default:
+			return false
+		}
+	default:
+		i := strings.LastIndex(line, textSig)
+		if i < 0 {
+			return false
+		}
+		body, sig = line[:i], line[i+len(textSig):]
+	}
+
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, sign([]byte(body), key))
+}
+
+// sign returns the HMAC-SHA256 of p keyed with key.
+func sign(p, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(p)
+	return mac.Sum(nil)
+}
+
+// auditWriter appends the signature of each entry written to it before
+// writing it to w.
+type auditWriter struct {
+	w   io.Writer
+	key []byte
+}
+
+// Write implements io.Writer. p must be a single entry.
+func (a *auditWriter) Write(p []byte) (int, error) {
+	return a.WriteLevel(noLevel, p)
+}
+
+// WriteLevel signs p, a single entry at level, and writes it to the
+// underlying writer.
+func (a *auditWriter) WriteLevel(level Level, p []byte) (int, error) {
+	body := bytes.TrimSuffix(p, []byte("\n"))
+	sig := hex.EncodeToString(sign(body, a.key))
+
+	b := make([]byte, 0, len(p)+len(SignatureKey)+len(sig)+6)
+	if len(body) >= 2 && body[0] == '{' && body[len(body)-1] == '}' {
+		b = append(b, body[:len(body)-1]...)
+		if len(body) > 2 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = append(b, SignatureKey...)
+		b = append(b, `":"`...)
+		b = append(b, sig...)
+		b = append(b, `"}`...)
+	} else {
+		b = append(b, body...)
+		b = append(b, ' ')
+		b = append(b, SignatureKey...)
+		b = append(b, '=')
+		b = append(b, sig...)
+	}
+	if len(body) < len(p) {
+		b = append(b, '\n')
+	}
+	if _, err := writeLevel(a.w, level, b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Unwrap returns the underlying writer.
+func (a *auditWriter) Unwrap() io.Writer {
+	return a.w
+}
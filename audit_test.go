@@ -0,0 +1,65 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditLogger(t *testing.T) {
+	key := []byte("secret")
+	cases := []struct {
+		name      string
+		formatter Formatter
+		prefix    string
+	}{
+		{"text", TextFormatter, "INFO login user=alice sig="},
+		{"json", JSONFormatter, `{"lvl":"info","msg":"login","user":"alice","sig":"`},
+		{"logfmt", LogfmtFormatter, "lvl=info msg=login user=alice sig="},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf)
+			l.SetFormatter(c.formatter)
+			al := NewAuditLogger(l, key)
+			al.Info("login", "user", "alice")
+			al.With("user", "bob").Warn("logout")
+			l.Info("unsigned")
+
+			lines := strings.SplitAfter(buf.String(), "\n")
+			require.Len(t, lines, 4)
+			require.True(t, strings.HasPrefix(lines[0], c.prefix), lines[0])
+			require.True(t, Verify(lines[0], key))
+			require.True(t, Verify(strings.TrimSuffix(lines[1], "\n"), key))
+			require.False(t, Verify(lines[2], key))
+			require.False(t, Verify(lines[0], []byte("other")))
+			require.False(t, Verify(strings.Replace(lines[0], "alice", "mallory", 1), key))
+		})
+	}
+}
+
+func TestVerify_malformed(t *testing.T) {
+	key := []byte("secret")
+	for _, line := range []string{
+		"",
+		"INFO hello",
+		"INFO hello sig=",
+		"INFO hello sig=zz",
+		`{"sig":"00"}`,
+		`{"msg":"hello"}`,
+		`{"msg":"hello" "sig":"00"}`,
+	} {
+		require.False(t, Verify(line, key), line)
+	}
+}
+
+func TestAuditWriter_emptyJSON(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := (&auditWriter{w: &buf, key: []byte("k")}).Write([]byte("{}\n"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), `{"sig":"`))
+	require.True(t, Verify(buf.String(), []byte("k")))
+}
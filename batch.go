@@ -0,0 +1,186 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrWriterClosed is returned when writing to a closed writer.
+var ErrWriterClosed = errors.New("writer closed")
+
+const (
+	defaultBatchSize     = 100
+	defaultBatchInterval = time.Second
+)
+
+// batchConfig is the configuration of a batching writer.
+type batchConfig struct {
+	size     int
+	interval time.Duration
+}
+
+// BatchOption is an option for writers that send entries in batches.
+type BatchOption func(*batchConfig)
+
+// WithBatchSize returns a batch option that sends entries once n of them are
+// pending.
+func WithBatchSize(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.size = n
+		}
+	}
+}
+
+// WithBatchInterval returns a batch option that sends pending entries every
+// interval, regardless of their number.
+func WithBatchInterval(interval time.Duration) BatchOption {
+	return func(c *batchConfig) {
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// batcher parses the entries written to it, and sends them in batches, when
+// enough of them are pending, periodically, and when flushed or closed.
+// Batches are sent one at a time, in order, without holding the lock, so that
+// writes don't wait for a slow periodic send.
+type batcher struct {
+	mu      sync.Mutex
+	size    int
+	send    func([]LogEntry) error
+	pending []LogEntry
+	err     error
+	closed  bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	// sending is true while a batch is being sent, and idle is signaled
+	// once it's sent.
+	sending bool
+	idle    *sync.Cond
+}
+
+// newBatcher returns a batcher that sends batches with send.
+func newBatcher(send func([]LogEntry) error, opts ...BatchOption) *batcher {
+	c := batchConfig{size: defaultBatchSize, interval: defaultBatchInterval}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	b := &batcher{
+		size: c.size,
+		send: send,
+		done: make(chan struct{}),
+	}
+	b.idle = sync.NewCond(&b.mu)
+	b.wg.Add(1)
+	go b.run(c.interval)
+	return b
+}
+
+func (b *batcher) run(interval time.Duration) {
+	defer b.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			b.mu.Lock()
+			if err := b.sendPending(1); err != nil {
+				b.err = err
+			}
+			b.mu.Unlock()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Write implements io.Writer. It parses the entries in p, written with the
+// text or JSON formatter. The error of the last failed batch, if any, is
+// returned.
+func (b *batcher) Write(p []byte) (int, error) {
+	entries, err := parseOutput(p)
+	if err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return 0, ErrWriterClosed
+	}
+	b.pending = append(b.pending, entries...)
+	if len(b.pending) >= b.size {
+		if err := b.sendPending(b.size); err != nil {
+			b.err = err
+		}
+	}
+	if err := b.err; err != nil {
+		b.err = nil
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+// Flush sends the pending entries, once the batch being sent, if any, is
+// sent.
+func (b *batcher) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.sending {
+		b.idle.Wait()
+	}
+	return b.sendPending(1)
+}
+
+// Close sends the pending entries and stops the batcher.
+func (b *batcher) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return b.Flush()
+}
+
+// sendPending sends the pending entries in batches, as long as at least min
+// of them are pending, and returns the last error. It must be called with the
+// lock held, which is released while sending. If a batch is already being
+// sent, it returns right away, leaving the pending entries to the next send.
+func (b *batcher) sendPending(min int) error {
+	if b.sending {
+		return nil
+	}
+	var err error
+	for len(b.pending) > 0 && len(b.pending) >= min {
+		batch := b.pending
+		b.pending = nil
+		b.sending = true
+		b.mu.Unlock()
+		serr := b.send(batch)
+		b.mu.Lock()
+		b.sending = false
+		b.idle.Broadcast()
+		if serr != nil {
+			err = serr
+		}
+	}
+	return err
+}
+
+// parseOutput parses entries written with the text or JSON formatter.
+func parseOutput(p []byte) ([]LogEntry, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		return ParseJSON(bytes.NewReader(p))
+	}
+	return Parse(bytes.NewReader(p))
+}
@@ -0,0 +1,106 @@
+package log
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]LogEntry
+	err     error
+}
+
+func (r *batchRecorder) send(entries []LogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, entries)
+	return r.err
+}
+
+func (r *batchRecorder) messages() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var msgs [][]string
+	for _, b := range r.batches {
+		var m []string
+		for _, e := range b {
+			m = append(m, e.Message)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs
+}
+
+func TestBatcher_size(t *testing.T) {
+	var r batchRecorder
+	b := newBatcher(r.send, WithBatchSize(2), WithBatchInterval(time.Hour))
+	l := New(b)
+	l.Info("one")
+	l.Info("two")
+	l.Info("three")
+	require.Equal(t, [][]string{{"one", "two"}}, r.messages())
+	require.NoError(t, b.Close())
+	require.Equal(t, [][]string{{"one", "two"}, {"three"}}, r.messages())
+
+	_, err := b.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrWriterClosed)
+	require.NoError(t, b.Close())
+}
+
+func TestBatcher_interval(t *testing.T) {
+	var r batchRecorder
+	b := newBatcher(r.send, WithBatchInterval(10*time.Millisecond))
+	defer b.Close()
+	NewWithOptions(b, Options{Formatter: JSONFormatter}).Info("json", "foo", "bar")
+	require.Eventually(t, func() bool {
+		return len(r.messages()) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, [][]string{{"json"}}, r.messages())
+}
+
+func TestBatcher_error(t *testing.T) {
+	r := batchRecorder{err: errors.New("boom")}
+	b := newBatcher(r.send, WithBatchSize(1), WithBatchInterval(time.Hour))
+	_, err := b.Write([]byte("INFO one\n"))
+	require.EqualError(t, err, "boom")
+	r.err = nil
+	_, err = b.Write([]byte("INFO two\n"))
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+}
+
+func TestBatcher_slowSend(t *testing.T) {
+	var r batchRecorder
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	b := newBatcher(func(entries []LogEntry) error {
+		select {
+		case started <- struct{}{}:
+			<-release
+		default:
+		}
+		return r.send(entries)
+	}, WithBatchInterval(10*time.Millisecond))
+	l := New(b)
+	l.Info("one")
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		l.Info("two")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("write blocked on a slow send")
+	}
+	close(release)
+	require.NoError(t, b.Close())
+	require.Equal(t, [][]string{{"one"}, {"two"}}, r.messages())
+}
@@ -0,0 +1,89 @@
+package log
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func BenchmarkLogInfo(b *testing.B) {
+	l := New(nopWriter{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world")
+	}
+}
+
+func BenchmarkLogWithFields(b *testing.B) {
+	l := New(nopWriter{}).With("service", "api")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world", "foo", "bar", "count", 42)
+	}
+}
+
+func BenchmarkLogJSON(b *testing.B) {
+	l := NewWithOptions(nopWriter{}, Options{Formatter: JSONFormatter})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world", "foo", "bar", "count", 42)
+	}
+}
+
+func BenchmarkLogFiltered(b *testing.B) {
+	l := New(nopWriter{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Debugf("hello %s", "world")
+	}
+}
+
+func BenchmarkLogDiscard(b *testing.B) {
+	l := New(ioutil.Discard)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world", "foo", "bar")
+	}
+}
+
+func BenchmarkLogAsync(b *testing.B) {
+	l := New(nopWriter{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("hello world", "foo", "bar")
+		}
+	})
+}
+
+func TestLogDiscardAllocs(t *testing.T) {
+	l := New(ioutil.Discard)
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Info("hello world", "foo", "bar")
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations when discarding, got %v", allocs)
+	}
+}
+
+func TestLogFilteredAllocs(t *testing.T) {
+	l := New(nopWriter{})
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Debug("hello world", "foo", "bar")
+		l.Debugf("hello %s", "world")
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations for filtered levels, got %v", allocs)
+	}
+}
+
+// nopWriter is a writer that discards its input, without being detected as
+// ioutil.Discard by the logger.
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
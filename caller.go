@@ -0,0 +1,66 @@
+package log
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WithFullCaller returns a new logger that reports the caller location,
+// including the function name, using FullCallerFormatter.
+func (l *Logger) WithFullCaller() *Logger {
+	sl := l.With()
+	sl.SetReportCaller(true)
+	sl.SetCallerFormatter(FullCallerFormatter)
+	return sl
+}
+
+// WithThreadedCaller returns a new logger that reports the caller location
+// prefixed with the ID of the logging goroutine, like
+// "goroutine-42/log/file.go:12". It helps to correlate the output of
+// concurrent goroutines. See WithGoroutineID for the cost of reading
+// goroutine IDs.
+func (l *Logger) WithThreadedCaller() *Logger {
+	sl := l.With()
+	sl.SetReportCaller(true)
+	sl.mu.Lock()
+	sl.threadedCaller = true
+	sl.mu.Unlock()
+	return sl
+}
+
+// WithSourcePrefix returns a logger option that reports the caller location
+// of files under prefix relative to it, like "internal/server/handler.go:42"
+// for the prefix "github.com/myorg/myapp", instead of using the caller
+// formatter. The prefix can appear anywhere in the path of the files: use the
+// module path when building with -trimpath, or the directory of the module
+// otherwise. Other files use the caller formatter.
+func WithSourcePrefix(prefix string) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.sourcePrefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// formatCaller formats the caller location, relative to the source prefix if
+// the file is under it.
+func (l *Logger) formatCaller(file string, line int, fn string) string {
+	if l.sourcePrefix != "" {
+		if i := strings.Index(file, l.sourcePrefix+"/"); i >= 0 {
+			return file[i+len(l.sourcePrefix)+1:] + ":" + strconv.Itoa(line)
+		}
+	}
+	return l.callerFormatter(file, line, fn)
+}
+
+// trimFuncName returns the function name without its package path, like
+// "(*Logger).Info" for "github.com/charmbracelet/log.(*Logger).Info".
+func trimFuncName(fn string) string {
+	if i := strings.LastIndexByte(fn, '/'); i >= 0 {
+		fn = fn[i+1:]
+	}
+	if i := strings.IndexByte(fn, '.'); i >= 0 {
+		fn = fn[i+1:]
+	}
+	return fn
+}
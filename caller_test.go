@@ -0,0 +1,63 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrimFuncName(t *testing.T) {
+	cases := map[string]string{
+		"github.com/charmbracelet/log.(*Logger).Info": "(*Logger).Info",
+		"github.com/charmbracelet/log.TestFoo.func1":  "TestFoo.func1",
+		"main.main": "main",
+		"":          "",
+	}
+	for fn, expected := range cases {
+		require.Equal(t, expected, trimFuncName(fn), fn)
+	}
+}
+
+func TestWithFullCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithFullCaller()
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("info")
+	require.Equal(t, fmt.Sprintf("INFO <log/caller_test.go:%d:TestWithFullCaller> info\n", line+1), buf.String())
+}
+
+func TestWithThreadedCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithThreadedCaller()
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("info")
+	expected := fmt.Sprintf("INFO <goroutine-%d/log/caller_test.go:%d> info\n", goroutineID(), line+1)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestWithSourcePrefix(t *testing.T) {
+	var buf bytes.Buffer
+	_, file, _, _ := runtime.Caller(0)
+	l := New(&buf, WithSourcePrefix(path.Dir(file)+"/"))
+	l.SetReportCaller(true)
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("info")
+	require.Equal(t, fmt.Sprintf("INFO <caller_test.go:%d> info\n", line+1), buf.String())
+}
+
+func TestFormatCaller(t *testing.T) {
+	l := New(nil, WithSourcePrefix("github.com/myorg/myapp"))
+	cases := map[string]string{
+		"github.com/myorg/myapp/internal/server/handler.go":         "internal/server/handler.go:42",
+		"/home/me/go/pkg/mod/github.com/myorg/myapp/main.go":        "main.go:42",
+		"github.com/myorg/myapplication/internal/server/handler.go": "server/handler.go:42",
+		"github.com/other/lib/lib.go":                               "lib/lib.go:42",
+	}
+	for file, expected := range cases {
+		require.Equal(t, expected, l.formatCaller(file, 42, "main.main"), file)
+	}
+}
@@ -0,0 +1,100 @@
+package log
+
+import (
+	"path"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// callerLevels are the levels of the call sites matching patterns.
+type callerLevels struct {
+	patterns []callerPattern // most specific first
+	min      Level
+	cache    sync.Map // call site PC -> callerLevel
+}
+
+// callerPattern is a call site pattern and its level.
+type callerPattern struct {
+	pattern string
+	level   Level
+}
+
+// callerLevel is the level override of a call site.
+type callerLevel struct {
+	level Level
+	ok    bool
+}
+
+// WithCallerLevels returns a logger option that overrides the level of the
+// logger for the entries logged from specific call sites. The keys of
+// overrides are path.Match patterns matching "dir/file.go:function" for the
+// call site, like "http/server.go:*" or "*/db.go:(*Conn).exec", and entries
+// from matching call sites are filtered by the pattern level instead of the
+// logger level. This silences noisy packages, or enables debug entries in a
+// single file, without changing the logger level.
+//
+// When several patterns match, the longest one wins. Invalid patterns never
+// match. The call site is looked up for every entry, so there's a small cost
+// to using this option, even for call sites that don't match.
+func WithCallerLevels(overrides map[string]Level) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(overrides) == 0 {
+			l.callerLevels = nil
+			return
+		}
+
+		c := &callerLevels{min: noLevel}
+		for p, level := range overrides {
+			c.patterns = append(c.patterns, callerPattern{pattern: p, level: level})
+			if level < c.min {
+				c.min = level
+			}
+		}
+		sort.Slice(c.patterns, func(i, j int) bool {
+			pi, pj := c.patterns[i].pattern, c.patterns[j].pattern
+			if len(pi) != len(pj) {
+				return len(pi) > len(pj)
+			}
+			return pi < pj
+		})
+		l.callerLevels = c
+	}
+}
+
+// callerEnabled returns true if entries at level are enabled for the call
+// site skip frames above the caller of callerEnabled.
+func (l *Logger) callerEnabled(skip int, level Level) bool {
+	var pcs [1]uintptr
+	// Skip runtime.Callers and callerEnabled.
+	if runtime.Callers(int(atomic.LoadInt32(&l.callerOffset))+skip+2, pcs[:]) == 0 {
+		return l.effectiveLevel() <= int32(level)
+	}
+
+	c := l.callerLevels
+	var cl callerLevel
+	if v, ok := c.cache.Load(pcs[0]); ok {
+		cl = v.(callerLevel)
+	} else {
+		frame, _ := runtime.CallersFrames(pcs[:]).Next()
+		cl = c.match(trimCallerPath(frame.File, 2) + ":" + trimFuncName(frame.Function))
+		c.cache.Store(pcs[0], cl)
+	}
+	if cl.ok {
+		return cl.level <= level
+	}
+	return l.effectiveLevel() <= int32(level)
+}
+
+// match returns the level of the most specific pattern matching site.
+func (c *callerLevels) match(site string) callerLevel {
+	for _, p := range c.patterns {
+		if ok, _ := path.Match(p.pattern, site); ok {
+			return callerLevel{level: p.level, ok: true}
+		}
+	}
+	return callerLevel{}
+}
@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func logFromHelper(l *Logger) {
+	l.Debug("helper debug")
+	l.Info("helper info")
+}
+
+func TestWithCallerLevels(t *testing.T) {
+	cases := []struct {
+		name      string
+		overrides map[string]Level
+		expected  string
+	}{
+		{
+			name:      "no match",
+			overrides: map[string]Level{"log/other.go:*": DebugLevel},
+			expected:  "INFO helper info\nINFO test info\n",
+		},
+		{
+			name:      "lower level",
+			overrides: map[string]Level{"log/callerlevel_test.go:logFromHelper": DebugLevel},
+			expected:  "DEBU helper debug\nINFO helper info\nINFO test info\n",
+		},
+		{
+			name:      "higher level",
+			overrides: map[string]Level{"log/callerlevel_test.go:*": ErrorLevel},
+			expected:  "",
+		},
+		{
+			name: "most specific",
+			overrides: map[string]Level{
+				"log/callerlevel_test.go:*":             ErrorLevel,
+				"log/callerlevel_test.go:logFromHelper": DebugLevel,
+			},
+			expected: "DEBU helper debug\nINFO helper info\n",
+		},
+		{
+			name:      "invalid pattern",
+			overrides: map[string]Level{"log/[.go:*": ErrorLevel},
+			expected:  "INFO helper info\nINFO test info\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, WithCallerLevels(c.overrides))
+			logFromHelper(l)
+			l.Debugf("test %s", "debug")
+			l.Infof("test %s", "info")
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWithCallerLevels_derived(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithCallerLevels(map[string]Level{"log/callerlevel_test.go:logFromHelper": DebugLevel}))
+	logFromHelper(l.With("foo", "bar"))
+	logFromHelper(l)
+	require.Equal(t, "DEBU helper debug foo=bar\nINFO helper info foo=bar\nDEBU helper debug\nINFO helper info\n", buf.String())
+}
@@ -0,0 +1,20 @@
+package log
+
+// WithCategory returns a new logger with the given category. Unlike the
+// prefix, the category isn't part of the output: it's available to pipeline
+// transformers and formatters in LogEntry.Category, to route entries by
+// domain, like sending all "security" entries to an audit log.
+func (l *Logger) WithCategory(category string) *Logger {
+	sl := l.With()
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.category = category
+	return sl
+}
+
+// GetCategory returns the category of the logger.
+func (l *Logger) GetCategory() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.category
+}
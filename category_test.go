@@ -0,0 +1,29 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCategory(t *testing.T) {
+	var buf, audit bytes.Buffer
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		if e.Category == "security" {
+			audit.WriteString(e.Message + "\n")
+			return nil
+		}
+		return e
+	}))
+	l := New(&buf, WithPipeline(p)).WithPrefix("app")
+	sl := l.WithCategory("security")
+	require.Equal(t, "", l.GetCategory())
+	require.Equal(t, "security", sl.GetCategory())
+	require.Equal(t, "security", sl.With("foo", "bar").GetCategory())
+
+	l.Info("request")
+	sl.Warn("login failed")
+	require.Equal(t, "INFO app: request\n", buf.String())
+	require.Equal(t, "login failed\n", audit.String())
+}
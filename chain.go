@@ -0,0 +1,58 @@
+package log
+
+import "io/ioutil"
+
+// chainStage is a logger of a chain, before the one writing entries.
+type chainStage struct {
+	l        *Logger
+	pipeline *Pipeline
+}
+
+// chain adapts the stages of a chain to a Transformer.
+type chain []chainStage
+
+// Transform implements Transformer. It drops the entry as soon as a stage
+// filters it out.
+func (c chain) Transform(e *LogEntry) *LogEntry {
+	for _, s := range c {
+		if int32(e.Level) < s.l.effectiveLevel() {
+			return nil
+		}
+		if s.pipeline != nil {
+			if e = s.pipeline.Transform(e); e == nil {
+				return nil
+			}
+		}
+	}
+	return e
+}
+
+// Chain returns a logger that passes every entry through loggers, in order.
+// Unlike a multi-writer, which broadcasts entries, only the last logger of the
+// chain writes entries: the previous ones filter them with their level, and
+// transform or drop them with their pipeline, before passing them on to the
+// next one. Processing stops as soon as a logger drops an entry.
+//
+// The returned logger has the options and fields of the last logger. Levels
+// are read for every entry, while pipelines must be set before calling
+// Chain. Chaining no loggers returns a logger that discards everything.
+func Chain(loggers ...*Logger) *Logger {
+	if len(loggers) == 0 {
+		return New(ioutil.Discard)
+	}
+	last := loggers[len(loggers)-1]
+	stages := make(chain, 0, len(loggers)-1)
+	for _, l := range loggers[:len(loggers)-1] {
+		l.mu.RLock()
+		stages = append(stages, chainStage{l: l, pipeline: l.pipeline})
+		l.mu.RUnlock()
+	}
+
+	sl := last.With()
+	transformers := []Transformer{stages}
+	if sl.pipeline != nil {
+		transformers = append(transformers, sl.pipeline.transformers...)
+	}
+	sl.pipeline = NewPipeline(transformers...)
+	return sl
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []string
+	filter := New(nil, WithPipeline(NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		calls = append(calls, "filter")
+		if strings.Contains(e.Message, "noisy") {
+			return nil
+		}
+		return e
+	}))))
+	filter.SetLevel(InfoLevel)
+	upper := New(nil, WithPipeline(NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		calls = append(calls, "upper")
+		e.Message = strings.ToUpper(e.Message)
+		return e
+	}))))
+	upper.SetLevel(DebugLevel)
+	out := New(&buf, WithPipeline(NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		calls = append(calls, "out")
+		return e
+	})))).With("app", "test")
+	out.SetLevel(DebugLevel)
+
+	l := Chain(filter, upper, out)
+	cases := []struct {
+		name     string
+		f        func()
+		expected string
+		calls    []string
+	}{
+		{
+			name:     "passed through",
+			f:        func() { l.Info("hello", "foo", "bar") },
+			expected: "INFO HELLO app=test foo=bar\n",
+			calls:    []string{"filter", "upper", "out"},
+		},
+		{
+			name:  "filtered by level",
+			f:     func() { l.Debug("hello") },
+			calls: nil,
+		},
+		{
+			name:  "dropped by pipeline",
+			f:     func() { l.Info("noisy") },
+			calls: []string{"filter"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			calls = nil
+			c.f()
+			require.Equal(t, c.expected, buf.String())
+			require.Equal(t, c.calls, calls)
+		})
+	}
+
+	// Levels of the chained loggers are read for every entry.
+	buf.Reset()
+	filter.SetLevel(DebugLevel)
+	l.Debug("debug")
+	require.Equal(t, "DEBU DEBUG app=test\n", buf.String())
+}
+
+func TestChain_empty(t *testing.T) {
+	require.NotPanics(t, func() {
+		Chain().Error("discarded")
+	})
+	var buf bytes.Buffer
+	Chain(New(&buf)).Info("hello")
+	require.Equal(t, "INFO hello\n", buf.String())
+}
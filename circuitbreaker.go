@@ -0,0 +1,78 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by writes that are discarded because the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreakerWriter is a writer that stops writing to the underlying
+// writer after too many consecutive failures.
+type circuitBreakerWriter struct {
+	w          io.Writer
+	maxErrors  int
+	resetAfter time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+}
+
+// Write implements io.Writer.
+func (c *circuitBreakerWriter) Write(p []byte) (int, error) {
+	return c.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p, an entry at level, to the underlying writer unless the
+// circuit breaker is open.
+func (c *circuitBreakerWriter) WriteLevel(level Level, p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	halfOpen := !c.openedAt.IsZero()
+	if halfOpen && time.Since(c.openedAt) < c.resetAfter {
+		return 0, ErrCircuitOpen
+	}
+
+	n, err := writeLevel(c.w, level, p)
+	if err != nil {
+		c.failures++
+		// A failed write while half-open trips the breaker again.
+		if halfOpen || c.failures >= c.maxErrors {
+			c.openedAt = time.Now()
+		}
+		return n, err
+	}
+
+	c.failures = 0
+	c.openedAt = time.Time{}
+	return n, nil
+}
+
+// Unwrap returns the underlying writer.
+func (c *circuitBreakerWriter) Unwrap() io.Writer {
+	return c.w
+}
+
+// WithCircuitBreaker returns a new logger that stops writing to its output
+// after maxErrors consecutive write failures. Entries are then discarded for
+// resetAfter, after which a single write is attempted to check whether the
+// output has recovered. This keeps a failing output from slowing down the
+// application.
+func (l *Logger) WithCircuitBreaker(maxErrors int, resetAfter time.Duration) *Logger {
+	if maxErrors < 1 {
+		maxErrors = 1
+	}
+	sl := l.With()
+	sl.w = &circuitBreakerWriter{
+		w:          sl.w,
+		maxErrors:  maxErrors,
+		resetAfter: resetAfter,
+	}
+	return sl
+}
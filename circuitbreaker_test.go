@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errWrite = errors.New("write failed")
+
+// failWriter is a writer that fails while fail is true.
+type failWriter struct {
+	bytes.Buffer
+	fail   bool
+	writes int
+}
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.fail {
+		return 0, errWrite
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	w := &failWriter{fail: true}
+	l := New(w).WithCircuitBreaker(2, 50*time.Millisecond)
+	l.Info("1")
+	l.Info("2")
+	require.Equal(t, 2, w.writes)
+
+	// The breaker is open, writes are discarded.
+	l.Info("3")
+	require.Equal(t, 2, w.writes)
+
+	// Half-open, the trial write fails and the breaker opens again.
+	time.Sleep(60 * time.Millisecond)
+	l.Info("4")
+	require.Equal(t, 3, w.writes)
+	l.Info("5")
+	require.Equal(t, 3, w.writes)
+
+	// Half-open, the trial write succeeds and the breaker closes.
+	w.fail = false
+	time.Sleep(60 * time.Millisecond)
+	l.Info("6")
+	l.Info("7")
+	require.Equal(t, 5, w.writes)
+	require.Equal(t, "INFO 6\nINFO 7\n", w.String())
+}
+
+func TestCircuitBreaker_reset(t *testing.T) {
+	w := &failWriter{fail: true}
+	l := New(w).WithCircuitBreaker(2, time.Hour)
+	l.Info("1")
+	w.fail = false
+	l.Info("2")
+	w.fail = true
+	l.Info("3")
+	w.fail = false
+	l.Info("4")
+	require.Equal(t, 4, w.writes)
+	require.Equal(t, "INFO 2\nINFO 4\n", w.String())
+}
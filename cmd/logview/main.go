@@ -0,0 +1,76 @@
+// Command logview reads log entries written with the text formatter from
+// standard input, and writes them back to standard output with colors and
+// aligned fields, or as JSON. It's meant to make logs written without colors,
+// like log files, readable again:
+//
+//	logview --level warn --search timeout < app.log
+//
+// Colors are only written when standard output is a terminal, unless the
+// CLICOLOR_FORCE environment variable is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "logview:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command with args, reading entries from r and writing them to
+// w. Usage and flag errors are written to errw.
+func run(args []string, r io.Reader, w, errw io.Writer) error {
+	fs := flag.NewFlagSet("logview", flag.ContinueOnError)
+	fs.SetOutput(errw)
+	level := fs.String("level", "", "only show entries at `level` or above: debug, info, warn, error, or fatal")
+	search := fs.String("search", "", "only show entries whose message contains `text`")
+	asJSON := fs.Bool("json", false, "write entries as JSON")
+	width := fs.Int("width", 40, "pad messages to `n` columns to align fields")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	minLevel := log.DebugLevel
+	if *level != "" {
+		minLevel = log.ParseLevel(*level)
+		if !strings.EqualFold(minLevel.String(), *level) {
+			return fmt.Errorf("invalid level %q", *level)
+		}
+	}
+
+	// The entries are written as is, replacing the ones created by Print.
+	var current log.LogEntry
+	l := log.New(w,
+		log.WithMinMessageLength(*width),
+		log.WithPipeline(log.NewPipeline(log.TransformerFunc(func(*log.LogEntry) *log.LogEntry {
+			e := current
+			return &e
+		}))),
+	)
+	if *asJSON {
+		l.SetFormatter(log.JSONFormatter)
+	}
+
+	// Entries are parsed and written one at a time, so that following a
+	// growing log file shows the entries as they're written.
+	return log.ParseFunc(r, func(e log.LogEntry) error {
+		if e.Level < minLevel || !strings.Contains(e.Message, *search) {
+			return nil
+		}
+		current = e
+		l.Print("")
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+const input = `2023-04-05 06:07:08 DEBU <app/main.go:10> starting
+2023-04-05 06:07:09 INFO <app/main.go:12> server: listening addr=:8080
+2023-04-05 06:07:10 WARN <app/main.go:20> server: request timeout path=/api took=5s
+plain message
+2023-04-05 06:07:11 ERRO <app/main.go:30> server: upstream timeout err="connection refused"
+`
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name: "text",
+			args: []string{"--width", "20"},
+			expected: `2023/04/05 06:07:08 DEBU <app/main.go:10> starting
+2023/04/05 06:07:09 INFO <app/main.go:12> server: listening            addr=:8080
+2023/04/05 06:07:10 WARN <app/main.go:20> server: request timeout      path=/api took=5s
+plain message
+2023/04/05 06:07:11 ERRO <app/main.go:30> server: upstream timeout     err="connection refused"
+`,
+		},
+		{
+			name: "level",
+			args: []string{"--level", "warn", "--width", "0"},
+			expected: `2023/04/05 06:07:10 WARN <app/main.go:20> server: request timeout path=/api took=5s
+plain message
+2023/04/05 06:07:11 ERRO <app/main.go:30> server: upstream timeout err="connection refused"
+`,
+		},
+		{
+			name: "search",
+			args: []string{"--search", "timeout", "--level", "error", "--width", "0"},
+			expected: `2023/04/05 06:07:11 ERRO <app/main.go:30> server: upstream timeout err="connection refused"
+`,
+		},
+		{
+			name: "json",
+			args: []string{"--json", "--search", "listening"},
+			expected: `{"addr":":8080","caller":"app/main.go:12","lvl":"info","msg":"listening","prefix":"server:","ts":"2023/04/05 06:07:09"}
+`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			require.NoError(t, run(c.args, strings.NewReader(input), &out, &errOut))
+			require.Equal(t, c.expected, out.String())
+			require.Empty(t, errOut.String())
+		})
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	var out, errOut bytes.Buffer
+	require.EqualError(t, run([]string{"--level", "loud"}, strings.NewReader(input), &out, &errOut), `invalid level "loud"`)
+	require.EqualError(t, run([]string{"app.log"}, strings.NewReader(input), &out, &errOut), "unexpected arguments: app.log")
+	require.Error(t, run([]string{"--unknown"}, strings.NewReader(input), &out, &errOut))
+	require.Contains(t, errOut.String(), "flag provided but not defined")
+	require.Empty(t, out.String())
+}
+
+func TestRun_streaming(t *testing.T) {
+	in, inw := io.Pipe()
+	outr, out := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- run([]string{"--width", "0"}, in, out, io.Discard)
+		out.Close()
+	}()
+
+	lines := bufio.NewReader(outr)
+	// An entry is complete once the next one starts.
+	_, err := io.WriteString(inw, "INFO first\nWARN second\n")
+	require.NoError(t, err)
+	line, err := lines.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "INFO first\n", line)
+
+	inw.Close()
+	line, err = lines.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "WARN second\n", line)
+	require.NoError(t, <-done)
+}
+
+func TestRun_multiline(t *testing.T) {
+	var in bytes.Buffer
+	l := log.New(&in)
+	l.Info("retrying", "err", "line1\nline2")
+	l.Error("failed", "err", "line3\nline4")
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"--level", "error", "--width", "0"}, &in, &out, io.Discard))
+	var expected bytes.Buffer
+	log.New(&expected).Error("failed", "err", "line3\nline4")
+	require.Equal(t, expected.String(), out.String())
+}
@@ -0,0 +1,99 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Any returns the key-value pairs of val under key. Slices and arrays are
+// expanded into one pair per element, like key[0]=v0 key[1]=v1, and maps into
+// one pair per entry, sorted by key, like key.k1=v1 key.k2=v2. Nested
+// collections are expanded too. Any other value, including empty collections
+// and byte slices, is returned as a single pair:
+//
+//	logger.Info("request", log.Any("tags", tags)...)
+//
+// See WithExpandCollections to expand all the collections of a logger.
+func Any(key string, val interface{}) []interface{} {
+	return expandValue(nil, key, val)
+}
+
+// WithExpandCollections returns a logger option that expands all the slice,
+// array, and map values of the entries, including the logger fields, like
+// Any does.
+func WithExpandCollections() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.expandCollections = true
+	}
+}
+
+// expandCollections expands the collection values of keyvals. keyvals is
+// returned as is if there's none.
+func expandCollections(keyvals []interface{}) []interface{} {
+	i := 0
+	for ; i+1 < len(keyvals); i += 2 {
+		if isCollection(keyvals[i+1]) {
+			break
+		}
+	}
+	if i+1 >= len(keyvals) {
+		return keyvals
+	}
+
+	expanded := make([]interface{}, 0, len(keyvals)+8)
+	expanded = append(expanded, keyvals[:i]...)
+	for ; i+1 < len(keyvals); i += 2 {
+		expanded = expandValue(expanded, fmt.Sprint(keyvals[i]), keyvals[i+1])
+	}
+	return expanded
+}
+
+// isCollection returns true if val is a non-empty slice, array, or map that
+// isn't a byte slice.
+func isCollection(val interface{}) bool {
+	if val == nil {
+		return false
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Len() > 0 && v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return v.Len() > 0
+	default:
+		return false
+	}
+}
+
+// expandValue appends the key-value pairs of val under key to kvs.
+func expandValue(kvs []interface{}, key string, val interface{}) []interface{} {
+	if !isCollection(val) {
+		return append(kvs, key, val)
+	}
+
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Map {
+		for i := 0; i < v.Len(); i++ {
+			kvs = expandValue(kvs, key+"["+strconv.Itoa(i)+"]", v.Index(i).Interface())
+		}
+		return kvs
+	}
+
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k := fmt.Sprint(iter.Key().Interface())
+		keys = append(keys, k)
+		values[k] = iter.Value().Interface()
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		kvs = expandValue(kvs, key+"."+k, values[k])
+	}
+	return kvs
+}
@@ -0,0 +1,81 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAny(t *testing.T) {
+	cases := []struct {
+		name     string
+		val      interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "slice",
+			val:      []string{"a", "b"},
+			expected: []interface{}{"k[0]", "a", "k[1]", "b"},
+		},
+		{
+			name:     "array",
+			val:      [2]int{1, 2},
+			expected: []interface{}{"k[0]", 1, "k[1]", 2},
+		},
+		{
+			name:     "map",
+			val:      map[string]string{"b": "2", "a": "1"},
+			expected: []interface{}{"k.a", "1", "k.b", "2"},
+		},
+		{
+			name: "nested",
+			val:  map[string]interface{}{"ids": []int{7, 8}, "name": "foo"},
+			expected: []interface{}{
+				"k.ids[0]", 7,
+				"k.ids[1]", 8,
+				"k.name", "foo",
+			},
+		},
+		{
+			name:     "empty",
+			val:      []string{},
+			expected: []interface{}{"k", []string{}},
+		},
+		{
+			name:     "bytes",
+			val:      []byte("raw"),
+			expected: []interface{}{"k", []byte("raw")},
+		},
+		{
+			name:     "scalar",
+			val:      42,
+			expected: []interface{}{"k", 42},
+		},
+		{
+			name:     "nil",
+			val:      nil,
+			expected: []interface{}{"k", nil},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, Any("k", c.val))
+		})
+	}
+}
+
+func TestWithExpandCollections(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithExpandCollections()).With("tags", []string{"a", "b"})
+	l.Info("hello", "headers", map[string]int{"x": 1}, "n", 1)
+	require.Equal(t, "INFO hello tags[0]=a tags[1]=b headers.x=1 n=1\n", buf.String())
+
+	buf.Reset()
+	New(&buf).Info("hello", "tags", []string{"a", "b"})
+	require.Equal(t, "INFO hello tags=\"[a b]\"\n", buf.String())
+
+	buf.Reset()
+	New(&buf).Info("hello", Any("tags", []string{"a", "b"})...)
+	require.Equal(t, "INFO hello tags[0]=a tags[1]=b\n", buf.String())
+}
@@ -1,6 +1,9 @@
 package log
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // WithContext wraps the given logger in context.
 func WithContext(ctx context.Context, logger *Logger) context.Context {
@@ -17,6 +20,48 @@ func FromContext(ctx context.Context) *Logger {
 	return defaultLogger
 }
 
+// WithDeadline returns a new logger that silently drops entries once the
+// deadline of ctx has passed. If ctx has no deadline, the returned logger
+// never drops entries.
+func (l *Logger) WithDeadline(ctx context.Context) *Logger {
+	sl := l.With()
+	if deadline, ok := ctx.Deadline(); ok {
+		sl.deadline = deadline
+	}
+	return sl
+}
+
+// ContextExtractor returns the key-value pairs to log from a context.
+type ContextExtractor func(ctx context.Context) []interface{}
+
+// WithContextExtractor returns a logger option that sets the function used to
+// extract fields from the context of loggers created with WithAutoContext.
+func WithContextExtractor(fn ContextExtractor) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.ctxExtractor = fn
+	}
+}
+
+// WithAutoContext returns a new logger that extracts fields from ctx with the
+// context extractor of the logger, on every logging call rather than once.
+// This keeps fields that change over time, like the current span ID, up to
+// date. The fields are added after the logger fields. Without an extractor,
+// no fields are added.
+func (l *Logger) WithAutoContext(ctx context.Context) *Logger {
+	sl := l.With()
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.autoCtx = ctx
+	return sl
+}
+
+// expired returns true if the logger deadline has passed.
+func (l *Logger) expired() bool {
+	return !l.deadline.IsZero() && !time.Now().Before(l.deadline)
+}
+
 type contextKey struct{}
 
 var loggerContextKey = contextKey{}
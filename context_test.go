@@ -5,6 +5,7 @@ import (
 	"context"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -29,3 +30,50 @@ func TestLogContext_fields(t *testing.T) {
 	l.Debug("test")
 	require.Equal(t, "DEBU test foo=bar\n", buf.String())
 }
+
+func TestLogContext_deadline(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	l := New(&buf).WithDeadline(ctx)
+	l.Info("before")
+	<-ctx.Done()
+	l.Info("after")
+	l.With("foo", "bar").Info("after")
+	require.Equal(t, "INFO before\n", buf.String())
+}
+
+func TestLogContext_noDeadline(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithDeadline(context.Background())
+	l.Info("info")
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+type spanKey struct{}
+
+type span struct{ id string }
+
+func TestLogContext_auto(t *testing.T) {
+	var buf bytes.Buffer
+	s := &span{id: "a"}
+	ctx := context.WithValue(context.Background(), spanKey{}, s)
+	l := New(&buf, WithContextExtractor(func(ctx context.Context) []interface{} {
+		if s, ok := ctx.Value(spanKey{}).(*span); ok {
+			return []interface{}{"span", s.id}
+		}
+		return nil
+	})).With("foo", "bar")
+
+	al := l.WithAutoContext(ctx)
+	al.Info("first", "n", 1)
+	s.id = "b"
+	al.Info("second")
+	l.Info("no context")
+	New(&buf).WithAutoContext(ctx).Info("no extractor")
+	expected := "INFO first foo=bar span=a n=1\n" +
+		"INFO second foo=bar span=b\n" +
+		"INFO no context foo=bar\n" +
+		"INFO no extractor\n"
+	require.Equal(t, expected, buf.String())
+}
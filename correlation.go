@@ -0,0 +1,58 @@
+package log
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+)
+
+// LogIDKey is the key of the field holding the correlation ID of an entry.
+var LogIDKey = "log_id"
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// WithAutoCorrelationID returns a logger option that adds a unique ULID to
+// every entry, under LogIDKey, so that individual entries can be referenced,
+// like in bug reports. ULIDs sort by time, with a millisecond precision.
+func WithAutoCorrelationID() LoggerOption {
+	return WithCorrelationIDFunc(newULID)
+}
+
+// WithCorrelationIDFunc returns a logger option that adds the ID returned by
+// fn to every entry, under LogIDKey. fn must be safe for concurrent use.
+func WithCorrelationIDFunc(fn func() string) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.correlationID = fn
+	}
+}
+
+// newULID returns a new ULID, made of the current time and random bits.
+func newULID() string {
+	var id [16]byte
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], ms)
+	copy(id[:6], ts[2:])
+	_, _ = rand.Read(id[6:])
+	return encodeULID(id)
+}
+
+// encodeULID encodes the 128 bits of a ULID as 26 Crockford base32
+// characters, the first one holding 2 bits of padding.
+func encodeULID(id [16]byte) string {
+	var dst [26]byte
+	for i := range dst {
+		var v byte
+		for b := 0; b < 5; b++ {
+			v <<= 1
+			if bit := i*5 + b - 2; bit >= 0 && id[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		dst[i] = crockford[v]
+	}
+	return string(dst[:])
+}
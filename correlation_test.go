@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeULID(t *testing.T) {
+	var id [16]byte
+	require.Equal(t, "00000000000000000000000000", encodeULID(id))
+
+	// 1469918176385 is 0x01563DF36481 milliseconds.
+	copy(id[:], []byte{0x01, 0x56, 0x3d, 0xf3, 0x64, 0x81})
+	require.Equal(t, "01ARYZ6S41", encodeULID(id)[:10])
+
+	for i := range id {
+		id[i] = 0xff
+	}
+	require.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID(id))
+}
+
+func TestWithAutoCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithAutoCorrelationID())
+	l.SetFormatter(JSONFormatter)
+
+	ids := map[string]bool{}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		buf.Reset()
+		l.Info("hello")
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+		id, _ := m[LogIDKey].(string)
+		require.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{26}$`, id)
+		ids[id] = true
+	}
+	require.Len(t, ids, 3)
+	require.LessOrEqual(t, encodeULID(ulidTime(start)), newULID())
+}
+
+// ulidTime returns a ULID with the time of t and no random bits.
+func ulidTime(t time.Time) [16]byte {
+	var id [16]byte
+	ms := t.UnixNano() / int64(time.Millisecond)
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+	return id
+}
+
+func TestWithCorrelationIDFunc(t *testing.T) {
+	var buf bytes.Buffer
+	n := 0
+	l := New(&buf, WithCorrelationIDFunc(func() string {
+		n++
+		return "id-" + string(rune('0'+n))
+	}))
+	l.Info("one")
+	l.Debug("filtered")
+	l.Warn("two", "foo", "bar")
+	require.Equal(t, "INFO one log_id=id-1\nWARN two foo=bar log_id=id-2\n", buf.String())
+}
@@ -0,0 +1,227 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Datadog trace correlation keys, see
+// https://docs.datadoghq.com/tracing/other_telemetry/connect_logs_and_traces/
+const (
+	DatadogTraceIDKey = "dd.trace_id"
+	DatadogSpanIDKey  = "dd.span_id"
+)
+
+const (
+	defaultDatadogSite = "datadoghq.com"
+	// maxDatadogBatch is the maximum number of logs in a single request.
+	maxDatadogBatch = 1000
+)
+
+// datadogStatuses maps levels to Datadog log statuses.
+var datadogStatuses = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+	FatalLevel: "critical",
+}
+
+// datadogConfig is the configuration of a Datadog writer.
+type datadogConfig struct {
+	client   *http.Client
+	url      string
+	hostname string
+	tags     []string
+	batch    []BatchOption
+}
+
+// DatadogOption is an option for a Datadog writer.
+type DatadogOption func(*datadogConfig)
+
+// WithDatadogClient returns a Datadog option that sends logs with client,
+// instead of http.DefaultClient.
+func WithDatadogClient(client *http.Client) DatadogOption {
+	return func(c *datadogConfig) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithDatadogSite returns a Datadog option that sends logs to the intake of
+// the given Datadog site, like "datadoghq.eu", instead of "datadoghq.com".
+func WithDatadogSite(site string) DatadogOption {
+	return func(c *datadogConfig) {
+		c.url = datadogIntakeURL(site)
+	}
+}
+
+// WithDatadogURL returns a Datadog option that sends logs to url, like a
+// proxy or an agent, instead of the intake of the Datadog site.
+func WithDatadogURL(url string) DatadogOption {
+	return func(c *datadogConfig) {
+		c.url = url
+	}
+}
+
+// WithDatadogHostname returns a Datadog option that reports hostname as the
+// host of the logs.
+func WithDatadogHostname(hostname string) DatadogOption {
+	return func(c *datadogConfig) {
+		c.hostname = hostname
+	}
+}
+
+// WithDatadogTags returns a Datadog option that adds tags, formatted like
+// "key:value", to the logs.
+func WithDatadogTags(tags ...string) DatadogOption {
+	return func(c *datadogConfig) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+// WithDatadogBatchSize returns a Datadog option that sends logs once n of
+// them are pending, up to 1000.
+func WithDatadogBatchSize(n int) DatadogOption {
+	return func(c *datadogConfig) {
+		if n > maxDatadogBatch {
+			n = maxDatadogBatch
+		}
+		c.batch = append(c.batch, WithBatchSize(n))
+	}
+}
+
+// WithDatadogFlushInterval returns a Datadog option that sends pending logs
+// every interval, regardless of their number.
+func WithDatadogFlushInterval(interval time.Duration) DatadogOption {
+	return func(c *datadogConfig) {
+		c.batch = append(c.batch, WithBatchInterval(interval))
+	}
+}
+
+// DatadogTrace returns the key-value pairs that correlate logs with a trace
+// of the Datadog APM tracer, from the IDs of its span context:
+//
+//	ctx := span.Context()
+//	logger.With(log.DatadogTrace(ctx.TraceID(), ctx.SpanID())...).Info("done")
+func DatadogTrace(traceID, spanID uint64) []interface{} {
+	return []interface{}{
+		DatadogTraceIDKey, strconv.FormatUint(traceID, 10),
+		DatadogSpanIDKey, strconv.FormatUint(spanID, 10),
+	}
+}
+
+// NewDatadogWriter returns a writer that sends log entries to the Datadog
+// Logs API, authenticated with apiKey.
+//
+// Each log has the given service, the env tag, and the status matching the
+// level of the entry. The prefix, caller, and fields of the entry are sent as
+// attributes, so that fields added with DatadogTrace correlate the log with
+// its trace. Entries can be written with the text or the JSON formatter, and
+// are sent in batches.
+//
+// Close the writer to send the pending logs and stop batching.
+func NewDatadogWriter(apiKey, service, env string, opts ...DatadogOption) (io.WriteCloser, error) {
+	if apiKey == "" {
+		return nil, errors.New("datadog: missing API key")
+	}
+	if service == "" {
+		return nil, errors.New("datadog: missing service")
+	}
+	c := datadogConfig{
+		client: http.DefaultClient,
+		url:    datadogIntakeURL(defaultDatadogSite),
+	}
+	if env != "" {
+		c.tags = append(c.tags, "env:"+env)
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	tags := strings.Join(c.tags, ",")
+	return newBatcher(func(entries []LogEntry) error {
+		return sendDatadog(&c, apiKey, service, tags, entries)
+	}, c.batch...), nil
+}
+
+// datadogIntakeURL returns the URL of the logs intake of a Datadog site.
+func datadogIntakeURL(site string) string {
+	return "https://http-intake.logs." + site + "/api/v2/logs"
+}
+
+// sendDatadog sends entries to Datadog, in chunks of at most 1000 logs.
+func sendDatadog(c *datadogConfig, apiKey, service, tags string, entries []LogEntry) error {
+	for len(entries) > 0 {
+		n := len(entries)
+		if n > maxDatadogBatch {
+			n = maxDatadogBatch
+		}
+		if err := postDatadog(c, apiKey, service, tags, entries[:n]); err != nil {
+			return err
+		}
+		entries = entries[n:]
+	}
+	return nil
+}
+
+// postDatadog sends a single request with entries to Datadog.
+func postDatadog(c *datadogConfig, apiKey, service, tags string, entries []LogEntry) error {
+	logs := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		m := fieldsMap(e.Fields)
+		m["service"] = service
+		m["message"] = e.Message
+		m["ddsource"] = "go"
+		if tags != "" {
+			m["ddtags"] = tags
+		}
+		if c.hostname != "" {
+			m["hostname"] = c.hostname
+		}
+		if status, ok := datadogStatuses[e.Level]; ok {
+			m["status"] = status
+		} else {
+			m["status"] = "info"
+		}
+		if !e.Time.IsZero() {
+			m["timestamp"] = e.Time.UnixNano() / int64(time.Millisecond)
+		}
+		if e.Prefix != "" {
+			m[PrefixKey] = e.Prefix
+		}
+		if e.Caller != "" {
+			m[CallerKey] = e.Caller
+		}
+		logs = append(logs, m)
+	}
+
+	body, err := json.Marshal(logs)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", apiKey)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("datadog: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatadogWriter(t *testing.T) {
+	var mu sync.Mutex
+	var requests [][]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("DD-API-KEY"))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var logs []map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&logs))
+		mu.Lock()
+		requests = append(requests, logs)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	w, err := NewDatadogWriter("secret", "api", "prod",
+		WithDatadogURL(srv.URL),
+		WithDatadogTags("team:core"),
+		WithDatadogHostname("host-1"),
+		WithDatadogFlushInterval(time.Hour),
+	)
+	require.NoError(t, err)
+
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	l := NewWithOptions(w, Options{
+		ReportTimestamp: true,
+		TimeFunction:    func() time.Time { return ts },
+		TimeFormat:      time.RFC3339,
+	})
+	l.With(DatadogTrace(123, 456)...).Info("hello", "foo", "bar")
+	_, err = w.Write([]byte("2023-04-05T06:07:08Z FATA crashed\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, [][]map[string]interface{}{{
+		{
+			"service":     "api",
+			"message":     "hello",
+			"ddsource":    "go",
+			"ddtags":      "env:prod,team:core",
+			"hostname":    "host-1",
+			"status":      "info",
+			"timestamp":   float64(ts.UnixNano() / int64(time.Millisecond)),
+			"dd.trace_id": "123",
+			"dd.span_id":  "456",
+			"foo":         "bar",
+		},
+		{
+			"service":   "api",
+			"message":   "crashed",
+			"ddsource":  "go",
+			"ddtags":    "env:prod,team:core",
+			"hostname":  "host-1",
+			"status":    "critical",
+			"timestamp": float64(ts.UnixNano() / int64(time.Millisecond)),
+		},
+	}}, requests)
+}
+
+func TestDatadogWriter_errors(t *testing.T) {
+	_, err := NewDatadogWriter("", "api", "prod")
+	require.Error(t, err)
+	_, err = NewDatadogWriter("secret", "", "prod")
+	require.Error(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	w, err := NewDatadogWriter("secret", "api", "", WithDatadogURL(srv.URL), WithDatadogBatchSize(1))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("INFO hello\n"))
+	require.EqualError(t, err, "datadog: 403 Forbidden: forbidden")
+	require.NoError(t, w.Close())
+}
+
+func TestWithDatadogSite(t *testing.T) {
+	var c datadogConfig
+	WithDatadogSite("datadoghq.eu")(&c)
+	require.Equal(t, "https://http-intake.logs.datadoghq.eu/api/v2/logs", c.url)
+}
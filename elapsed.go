@@ -0,0 +1,54 @@
+package log
+
+import "time"
+
+var (
+	// ElapsedKey is the key of the elapsed time field.
+	ElapsedKey = "elapsed"
+	// DeltaKey is the key of the delta time field.
+	DeltaKey = "dt"
+)
+
+// roundDuration rounds d to keep it human readable.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second)
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d
+	}
+}
+
+// WithElapsedTime returns a logger option that appends an "elapsed" field to
+// every entry with the time elapsed since the logger was created, or since
+// the last call to ResetTimer.
+func WithElapsedTime() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.reportElapsed = true
+		l.start = l.timeFunc()
+	}
+}
+
+// ResetTimer resets the start time of the elapsed time field.
+func (l *Logger) ResetTimer() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.start = l.timeFunc()
+}
+
+// WithDeltaTime returns a logger option that appends a "dt" field to every
+// entry with the time elapsed since the previous entry of the logger. The
+// first entry reports "dt=0s".
+func WithDeltaTime() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.reportDelta = true
+	}
+}
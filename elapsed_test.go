@@ -0,0 +1,46 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElapsedTime(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(&buf)
+	l.SetTimeFunction(func() time.Time { return now })
+	WithElapsedTime()(l)
+	l.Info("start")
+	now = now.Add(1234567 * time.Microsecond)
+	l.Info("step", "foo", "bar")
+	now = now.Add(2 * time.Minute)
+	l.Info("step")
+	l.ResetTimer()
+	now = now.Add(1500 * time.Microsecond)
+	l.Info("reset")
+	require.Equal(t, "INFO start elapsed=0s\n"+
+		"INFO step foo=bar elapsed=1.23s\n"+
+		"INFO step elapsed=2m1s\n"+
+		"INFO reset elapsed=1.5ms\n", buf.String())
+}
+
+func TestDeltaTime(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(&buf, WithDeltaTime())
+	l.SetTimeFunction(func() time.Time { return now })
+	l.Info("first")
+	now = now.Add(42 * time.Millisecond)
+	l.Info("second")
+	now = now.Add(3 * time.Second)
+	l.Debug("filtered")
+	require.Equal(t, "INFO peek dt=3s\n", string(l.Formatted(InfoLevel, "peek")))
+	l.Info("third")
+	require.Equal(t, "INFO first dt=0s\n"+
+		"INFO second dt=42ms\n"+
+		"INFO third dt=3s\n", buf.String())
+}
@@ -0,0 +1,130 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// LogEntry is a log entry before it's formatted.
+type LogEntry struct {
+	// Time is the time of the entry. It's zero when timestamps aren't
+	// reported.
+	Time time.Time
+	// Level is the level of the entry.
+	Level Level
+	// Prefix is the prefix of the logger.
+	Prefix string
+	// Category is the category of the logger, set with WithCategory.
+	Category string
+	// Caller is the formatted caller location. It's empty when the caller
+	// isn't reported.
+	Caller string
+	// Message is the message of the entry.
+	Message string
+	// Fields are the key-value pairs of the entry, including the logger
+	// fields.
+	Fields []interface{}
+	// SensitiveKeys are the keys marked as sensitive on the logger with
+	// WithSensitiveKey. It must not be modified.
+	SensitiveKeys []string
+
+	// hasMessage is whether the entry was logged with a non-nil message.
+	hasMessage bool
+	// now is the time the entry was created.
+	now time.Time
+	// progress is whether the entry is a progress line, see Logger.Progress.
+	progress bool
+}
+
+// entry returns the log entry for a logging call, or nil if the entry is
+// dropped. skip is the number of stack frames between the caller of entry and
+// the logging call site.
+func (l *Logger) entry(skip int, level Level, msg interface{}, keyvals ...interface{}) *LogEntry {
+	e := &LogEntry{
+		Level:         level,
+		Prefix:        l.prefix,
+		Category:      l.category,
+		SensitiveKeys: l.sensitiveKeys,
+	}
+
+	if l.reportTimestamp || l.reportElapsed || l.reportDelta {
+		e.now = l.timeFunc()
+	}
+
+	if l.reportTimestamp {
+		e.Time = e.now
+	}
+
+	if l.reportCaller {
+		// Skip entry itself.
+		file, line, fn := l.fillLoc(int(atomic.LoadInt32(&l.callerOffset)) + skip + 1)
+		e.Caller = l.formatCaller(file, line, fn)
+		if l.threadedCaller {
+			e.Caller = "goroutine-" + strconv.FormatUint(goroutineID(), 10) + "/" + e.Caller
+		}
+	}
+
+	if msg != nil {
+		e.Message = l.msgPrefix + fmt.Sprint(msg) + l.msgSuffix
+		e.hasMessage = true
+	}
+
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals)+2)
+	// append logger fields
+	fields = append(fields, l.fields...)
+	if len(l.fields)%2 != 0 {
+		fields = append(fields, ErrMissingValue)
+	}
+	// append the context fields
+	if l.autoCtx != nil && l.ctxExtractor != nil {
+		ctxFields := l.ctxExtractor(l.autoCtx)
+		fields = append(fields, ctxFields...)
+		if len(ctxFields)%2 != 0 {
+			fields = append(fields, ErrMissingValue)
+		}
+	}
+	// append the rest
+	fields = appendGroup(fields, l.group, keyvals)
+	if len(keyvals)%2 != 0 {
+		fields = append(fields, ErrMissingValue)
+	}
+	if l.strictKeys {
+		fields = validateKeys(fields)
+	}
+	if l.expandCollections {
+		fields = expandCollections(fields)
+	}
+	if l.typeAnnotations {
+		annotateTypes(fields)
+	}
+	if l.reportElapsed {
+		elapsed := e.now.Sub(l.start)
+		fields = append(fields, ElapsedKey, roundDuration(elapsed))
+	}
+	if l.reportDelta {
+		var dt time.Duration
+		if last := atomic.LoadInt64(&l.lastLog); last != 0 {
+			dt = time.Duration(e.now.UnixNano() - last)
+		}
+		fields = append(fields, DeltaKey, roundDuration(dt))
+	}
+	if l.reportGoroutine {
+		fields = append(fields, GoroutineKey, goroutineID())
+	}
+	if l.correlationID != nil {
+		fields = append(fields, LogIDKey, l.correlationID())
+	}
+	if l.stackOnError && level >= ErrorLevel && level != noLevel && !hasKey(fields, StackKey) {
+		// Skip entry itself.
+		fields = append(fields, StackKey, callStack(int(atomic.LoadInt32(&l.callerOffset))+skip+1))
+	}
+	e.Fields = fields
+
+	if l.pipeline != nil {
+		e = l.pipeline.Transform(e)
+	}
+
+	return e
+}
@@ -0,0 +1,86 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructuredError converts err into a map, suitable for embedding in larger
+// log payloads or sending to error-tracking services. The map holds the
+// error "message" and "type", the "cause" if err wraps another error, and
+// the "stack" if err has a StackTrace method, like errors created by
+// github.com/pkg/errors. The cause is converted recursively. It returns nil
+// if err is nil.
+func StructuredError(err error) map[string]interface{} {
+	if err == nil {
+		return nil
+	}
+
+	m := map[string]interface{}{
+		"message": err.Error(),
+		"type":    fmt.Sprintf("%T", err),
+	}
+	if cause := errors.Unwrap(err); cause != nil {
+		m["cause"] = StructuredError(cause)
+	}
+	if stack := errorStack(err); stack != "" {
+		m["stack"] = stack
+	}
+	return m
+}
+
+// errorStack returns the formatted stack trace of err, if it has a
+// StackTrace method with no arguments and a single return value.
+func errorStack(err error) string {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return ""
+	}
+	typ := method.Type()
+	if typ.NumIn() != 0 || typ.NumOut() != 1 {
+		return ""
+	}
+	st := method.Call(nil)[0].Interface()
+	return strings.TrimSpace(fmt.Sprintf("%+v", st))
+}
+
+// ErrLevel returns the level an error should be logged at. By default, a nil
+// error maps to DebugLevel, context.Canceled to InfoLevel,
+// context.DeadlineExceeded to WarnLevel, and any other error to ErrorLevel.
+// The mapping can be changed with WithErrorLevelMapper.
+func (l *Logger) ErrLevel(err error) Level {
+	l.mu.RLock()
+	mapper := l.errLevelMapper
+	l.mu.RUnlock()
+	if mapper != nil {
+		return mapper(err)
+	}
+	return defaultErrLevel(err)
+}
+
+// WithErrorLevelMapper returns a logger option that sets the function used
+// by ErrLevel to map errors to levels.
+func WithErrorLevelMapper(fn func(error) Level) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.errLevelMapper = fn
+	}
+}
+
+// defaultErrLevel is the default mapping of errors to levels.
+func defaultErrLevel(err error) Level {
+	switch {
+	case err == nil:
+		return DebugLevel
+	case errors.Is(err, context.Canceled):
+		return InfoLevel
+	case errors.Is(err, context.DeadlineExceeded):
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
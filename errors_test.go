@@ -0,0 +1,105 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stackError struct {
+	msg   string
+	stack []string
+}
+
+func (e *stackError) Error() string { return e.msg }
+
+func (e *stackError) StackTrace() stackTrace { return e.stack }
+
+type stackTrace []string
+
+func (s stackTrace) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, strings.Join(s, "\n"))
+}
+
+func TestStructuredError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected map[string]interface{}
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name: "simple",
+			err:  errors.New("foo"),
+			expected: map[string]interface{}{
+				"message": "foo",
+				"type":    "*errors.errorString",
+			},
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("bar: %w", errors.New("foo")),
+			expected: map[string]interface{}{
+				"message": "bar: foo",
+				"type":    "*fmt.wrapError",
+				"cause": map[string]interface{}{
+					"message": "foo",
+					"type":    "*errors.errorString",
+				},
+			},
+		},
+		{
+			name: "stack",
+			err:  &stackError{msg: "foo", stack: []string{"main.go:1", "main.go:2"}},
+			expected: map[string]interface{}{
+				"message": "foo",
+				"type":    "*log.stackError",
+				"stack":   "main.go:1\nmain.go:2",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, StructuredError(c.err))
+		})
+	}
+}
+
+func TestErrLevel(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected Level
+	}{
+		{name: "nil", err: nil, expected: DebugLevel},
+		{name: "canceled", err: context.Canceled, expected: InfoLevel},
+		{name: "deadline", err: fmt.Errorf("foo: %w", context.DeadlineExceeded), expected: WarnLevel},
+		{name: "other", err: errors.New("foo"), expected: ErrorLevel},
+	}
+	l := New(ioutil.Discard)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, l.ErrLevel(c.err))
+		})
+	}
+}
+
+func TestWithErrorLevelMapper(t *testing.T) {
+	l := New(ioutil.Discard, WithErrorLevelMapper(func(err error) Level {
+		if err == nil {
+			return InfoLevel
+		}
+		return FatalLevel
+	}))
+	require.Equal(t, InfoLevel, l.ErrLevel(nil))
+	require.Equal(t, FatalLevel, l.ErrLevel(context.Canceled))
+}
@@ -0,0 +1,105 @@
+package log
+
+import "sync"
+
+// EventHandler handles the events logged with Logger.Event. keyvals are the
+// logger fields followed by the key-value pairs of the event, and must not
+// be modified.
+type EventHandler func(name string, keyvals []interface{})
+
+// eventHandlers are the event handlers registered on a logger. The lists are
+// replaced, never modified, so they can be read without holding the lock.
+type eventHandlers struct {
+	mu       sync.RWMutex
+	ids      []string
+	handlers []EventHandler
+}
+
+// Event logs the event name, with keyvals, at info level, and calls the
+// registered event handlers with it. The handlers of the logger are called
+// first, in the order they were registered, followed by the handlers of the
+// loggers it's derived from. The handlers are called even if info entries are
+// filtered, so that events can feed an event bus, or analytics, whatever the
+// logger level.
+func (l *Logger) Event(name string, keyvals ...interface{}) {
+	l.log(InfoLevel, name, keyvals...)
+
+	var handlers []EventHandler
+	for p := l; p != nil; p = p.parent {
+		p.mu.RLock()
+		e := p.events
+		p.mu.RUnlock()
+		if e == nil {
+			continue
+		}
+		e.mu.RLock()
+		handlers = append(handlers, e.handlers...)
+		e.mu.RUnlock()
+	}
+	if len(handlers) == 0 {
+		return
+	}
+
+	kvs := make([]interface{}, 0, len(l.fields)+len(keyvals)+1)
+	kvs = append(kvs, l.fields...)
+	if len(l.fields)%2 != 0 {
+		kvs = append(kvs, ErrMissingValue)
+	}
+	kvs = append(kvs, keyvals...)
+	for _, h := range handlers {
+		h(name, kvs)
+	}
+}
+
+// eventRegistry returns the event handlers of l, creating them if needed.
+func (l *Logger) eventRegistry() *eventHandlers {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.events == nil {
+		l.events = &eventHandlers{}
+	}
+	return l.events
+}
+
+// RegisterEventHandler registers h under id to handle the events of the
+// logger, and of the loggers derived from it, but not the events of the
+// logger it's derived from. A handler already registered on the logger under
+// id is replaced.
+func (l *Logger) RegisterEventHandler(id string, h EventHandler) {
+	e := l.eventRegistry()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, hid := range e.ids {
+		if hid == id {
+			handlers := make([]EventHandler, len(e.handlers))
+			copy(handlers, e.handlers)
+			handlers[i] = h
+			e.handlers = handlers
+			return
+		}
+	}
+	e.ids = append(e.ids[:len(e.ids):len(e.ids)], id)
+	e.handlers = append(e.handlers[:len(e.handlers):len(e.handlers)], h)
+}
+
+// UnregisterEventHandler removes the event handler registered on the logger
+// under id, if any.
+func (l *Logger) UnregisterEventHandler(id string) {
+	l.mu.RLock()
+	e := l.events
+	l.mu.RUnlock()
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ids := make([]string, 0, len(e.ids))
+	handlers := make([]EventHandler, 0, len(e.handlers))
+	for i, hid := range e.ids {
+		if hid != id {
+			ids = append(ids, hid)
+			handlers = append(handlers, e.handlers[i])
+		}
+	}
+	e.ids, e.handlers = ids, handlers
+}
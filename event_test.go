@@ -0,0 +1,67 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).With("app", "shop")
+	var events []string
+	l.RegisterEventHandler("analytics", func(name string, keyvals []interface{}) {
+		events = append(events, fmt.Sprint("analytics ", name, keyvals))
+	})
+	l.RegisterEventHandler("audit", func(name string, keyvals []interface{}) {
+		events = append(events, fmt.Sprint("audit ", name, keyvals))
+	})
+
+	l.With("user", "alice").Event("checkout", "total", 42)
+	require.Equal(t, "INFO checkout app=shop user=alice total=42\n", buf.String())
+	require.Equal(t, []string{
+		"analytics checkout[app shop user alice total 42]",
+		"audit checkout[app shop user alice total 42]",
+	}, events)
+
+	events = nil
+	l.UnregisterEventHandler("analytics")
+	l.UnregisterEventHandler("unknown")
+	l.Event("refund")
+	require.Equal(t, []string{"audit refund[app shop]"}, events)
+}
+
+func TestEvent_filtered(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(ErrorLevel)
+	var names []string
+	l.RegisterEventHandler("h", func(name string, _ []interface{}) { names = append(names, name+"1") })
+	l.RegisterEventHandler("h", func(name string, _ []interface{}) { names = append(names, name+"2") })
+	l.Event("signup")
+	require.Empty(t, buf.String())
+	require.Equal(t, []string{"signup2"}, names)
+}
+
+func TestEvent_derived(t *testing.T) {
+	l := New(nil)
+	child := l.With("child", true)
+	sibling := l.With("sibling", true)
+	var names []string
+	child.RegisterEventHandler("child", func(name string, _ []interface{}) { names = append(names, "child "+name) })
+	l.RegisterEventHandler("root", func(name string, _ []interface{}) { names = append(names, "root "+name) })
+
+	l.Event("a")
+	sibling.Event("b")
+	child.Event("c")
+	child.With("grandchild", true).Event("d")
+	require.Equal(t, []string{"root a", "root b", "child c", "root c", "child d", "root d"}, names)
+
+	names = nil
+	child.UnregisterEventHandler("root")
+	sibling.UnregisterEventHandler("child")
+	child.Event("e")
+	require.Equal(t, []string{"child e", "root e"}, names)
+}
@@ -0,0 +1,105 @@
+package log
+
+import "sync"
+
+// exportList is the list of loggers that log calls are mirrored to.
+type exportList struct {
+	mu   sync.RWMutex
+	dsts []*export
+}
+
+// export is a registration of a logger in an export list.
+type export struct {
+	dst *Logger
+}
+
+// ExportTo mirrors all future log calls on l, and on the loggers derived
+// from it, whether before or after ExportTo is called, to dst, and returns a function that stops mirroring them. Unlike
+// replacing the output of l, it's additive and can be undone at runtime.
+//
+// dst receives the level, message, logger fields, and key-value pairs of
+// every log call, whether l writes the entry or not, and filters and formats
+// it with its own options. The reported caller is the original call site.
+// dst must not export log calls back to l.
+func (l *Logger) ExportTo(dst *Logger) func() {
+	l.mu.Lock()
+	x, _ := l.exports.Load().(*exportList)
+	if x == nil {
+		x = &exportList{}
+		l.exports.Store(x)
+	}
+	l.mu.Unlock()
+
+	e := &export{dst: dst}
+	x.mu.Lock()
+	x.dsts = append(x.dsts[:len(x.dsts):len(x.dsts)], e)
+	x.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			x.mu.Lock()
+			defer x.mu.Unlock()
+			dsts := make([]*export, 0, len(x.dsts))
+			for _, d := range x.dsts {
+				if d != e {
+					dsts = append(dsts, d)
+				}
+			}
+			x.dsts = dsts
+		})
+	}
+}
+
+// skipFormat reports whether a log call at level can be dropped before its
+// message is formatted, because l doesn't write it and doesn't mirror it to
+// other loggers.
+func (l *Logger) skipFormat(level Level) bool {
+	return !l.enabled(level) && !l.exporting()
+}
+
+// exporting reports whether log calls on l are mirrored to other loggers,
+// registered with ExportTo on l or on the loggers it's derived from.
+func (l *Logger) exporting() bool {
+	for p := l; p != nil; p = p.parent {
+		if x, _ := p.exports.Load().(*exportList); x != nil {
+			x.mu.RLock()
+			n := len(x.dsts)
+			x.mu.RUnlock()
+			if n > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exportDsts returns the loggers that log calls on l are mirrored to.
+func (l *Logger) exportDsts() []*export {
+	var dsts []*export
+	for p := l; p != nil; p = p.parent {
+		if x, _ := p.exports.Load().(*exportList); x != nil {
+			x.mu.RLock()
+			dsts = append(dsts, x.dsts...)
+			x.mu.RUnlock()
+		}
+	}
+	return dsts
+}
+
+// mirror mirrors a log call of l to dsts. depth is the number of frames
+// between the caller of mirror and the call site.
+func mirror(depth int, l *Logger, dsts []*export, level Level, msg interface{}, keyvals []interface{}) {
+	kvs := make([]interface{}, 0, len(l.fields)+len(keyvals)+1)
+	kvs = append(kvs, l.fields...)
+	if len(l.fields)%2 != 0 {
+		kvs = append(kvs, ErrMissingValue)
+	}
+	kvs = append(kvs, keyvals...)
+	for _, d := range dsts {
+		if d.dst != l {
+			// Skip mirror itself.
+			d.dst.logDepth(depth+1, level, msg, kvs...)
+		}
+	}
+}
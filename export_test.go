@@ -0,0 +1,87 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTo(t *testing.T) {
+	var src, dst bytes.Buffer
+	l := New(&src).With("app", "test")
+	l.SetLevel(WarnLevel)
+	d := New(&dst)
+	d.SetReportCaller(true)
+	d.SetLevel(DebugLevel)
+
+	stop := l.ExportTo(d)
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("hello", "foo", "bar")
+	l.With("a", 1).Error("failed")
+	require.Equal(t, "ERRO failed app=test a=1\n", src.String())
+	require.Equal(t, fmt.Sprintf(
+		"INFO <log/export_test.go:%d> hello app=test foo=bar\nERRO <log/export_test.go:%d> failed app=test a=1\n",
+		line+1, line+2,
+	), dst.String())
+
+	stop()
+	stop()
+	src.Reset()
+	dst.Reset()
+	l.Warn("done")
+	require.Equal(t, "WARN done app=test\n", src.String())
+	require.Empty(t, dst.String())
+}
+
+func TestExportTo_multiple(t *testing.T) {
+	var src, a, b bytes.Buffer
+	l := New(&src)
+	stopA := l.ExportTo(New(&a))
+	stopB := l.ExportTo(New(&b))
+	l.ExportTo(l)
+	l.Info("one")
+	stopA()
+	l.Info("two")
+	stopB()
+	l.Info("three")
+	require.Equal(t, "INFO one\nINFO two\nINFO three\n", src.String())
+	require.Equal(t, "INFO one\n", a.String())
+	require.Equal(t, "INFO one\nINFO two\n", b.String())
+}
+
+func TestExportTo_filtered(t *testing.T) {
+	var src, dst bytes.Buffer
+	l := New(&src)
+	l.SetLevel(ErrorLevel)
+	d := New(&dst)
+	d.SetReportCaller(true)
+	l.ExportTo(d)
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Infof("hello %s", "world")
+	l.Warnln("careful", 1)
+	require.Empty(t, src.String())
+	require.Equal(t, fmt.Sprintf(
+		"INFO <log/export_test.go:%d> hello world\nWARN <log/export_test.go:%d> careful 1\n",
+		line+1, line+2,
+	), dst.String())
+}
+
+func TestExportTo_derivedBefore(t *testing.T) {
+	var dst bytes.Buffer
+	l := New(nil)
+	sub := l.With("sub", true)
+	stop := l.ExportTo(New(&dst))
+	sub.Info("hello")
+	sub.ExportTo(New(nil))
+	l.Info("parent")
+	require.Equal(t, "INFO hello sub=true\nINFO parent\n", dst.String())
+
+	require.False(t, l.skipFormat(DebugLevel))
+	stop()
+	require.True(t, l.skipFormat(DebugLevel))
+	require.False(t, sub.skipFormat(DebugLevel))
+}
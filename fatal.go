@@ -0,0 +1,23 @@
+package log
+
+import "os"
+
+// WithPanicOnCritical returns a logger option that makes fatal entries,
+// logged with Fatal, Fatalf, or Log and LogIf at FatalLevel, panic with the
+// message instead of exiting the program when enabled is true. This lets the
+// application decide what happens on fatal errors, by recovering from the
+// panic, when the logger is used by a library.
+func WithPanicOnCritical(enabled bool) LoggerOption {
+	return func(l *Logger) {
+		l.panicOnCritical = enabled
+	}
+}
+
+// exit ends the program after a fatal entry, or panics with msg if the logger
+// panics on critical entries.
+func (l *Logger) exit(msg interface{}) {
+	if l.panicOnCritical {
+		panic(msg)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,64 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPanicOnCritical(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithPanicOnCritical(true))
+	err := errors.New("boom")
+	cases := []struct {
+		name     string
+		f        func()
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "fatal",
+			f:        func() { l.Fatal(err, "foo", "bar") },
+			value:    err,
+			expected: "FATA boom foo=bar\n",
+		},
+		{
+			name:     "fatalf",
+			f:        func() { l.Fatalf("failed: %d", 42) },
+			value:    "failed: 42",
+			expected: "FATA failed: 42\n",
+		},
+		{
+			name:     "log",
+			f:        func() { l.Log(FatalLevel, "crashed") },
+			value:    "crashed",
+			expected: "FATA crashed\n",
+		},
+		{
+			name:     "log if",
+			f:        func() { l.LogIf(true, FatalLevel, "crashed") },
+			value:    "crashed",
+			expected: "FATA crashed\n",
+		},
+		{
+			name:     "std logger",
+			f:        func() { NewStdLogger(l).Fatalln("crashed") },
+			value:    "crashed",
+			expected: "FATA crashed\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			require.PanicsWithValue(t, c.value, c.f)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWithPanicOnCritical_disabled(t *testing.T) {
+	l := New(nil, WithPanicOnCritical(true), WithPanicOnCritical(false))
+	require.False(t, l.panicOnCritical)
+}
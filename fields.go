@@ -0,0 +1,85 @@
+package log
+
+// ErrorKey is the key of the field holding the error of loggers created with
+// WithError.
+var ErrorKey = "err"
+
+// WithField returns a new logger with the field key=val. It's equivalent to
+// With(key, val), but can't be called with a missing value.
+func (l *Logger) WithField(key string, val interface{}) *Logger {
+	return l.With(key, val)
+}
+
+// WithString returns a new logger with the string field key=val.
+func (l *Logger) WithString(key, val string) *Logger {
+	return l.WithField(key, val)
+}
+
+// WithInt returns a new logger with the integer field key=val.
+func (l *Logger) WithInt(key string, val int) *Logger {
+	return l.WithField(key, val)
+}
+
+// WithFloat64 returns a new logger with the floating-point field key=val.
+func (l *Logger) WithFloat64(key string, val float64) *Logger {
+	return l.WithField(key, val)
+}
+
+// WithBool returns a new logger with the boolean field key=val.
+func (l *Logger) WithBool(key string, val bool) *Logger {
+	return l.WithField(key, val)
+}
+
+// WithError returns a new logger with err under ErrorKey.
+func (l *Logger) WithError(err error) *Logger {
+	return l.WithField(ErrorKey, err)
+}
+
+// Fields builds the key-value pairs of an entry with typed setters, as an
+// alternative to passing keys and values as bare interface{} arguments, where
+// a missing key or value goes unnoticed until the entry is written:
+//
+//	fields := log.NewFields().
+//		SetString("user", name).
+//		SetInt("attempt", n).
+//		SetError("err", err)
+//	logger.Error("login failed", fields.Build()...)
+//
+// The zero value is an empty builder, ready to use.
+type Fields struct {
+	keyvals []interface{}
+}
+
+// NewFields returns an empty fields builder.
+func NewFields() *Fields {
+	return &Fields{}
+}
+
+// Set adds the key-value pair key=val, and returns f.
+func (f *Fields) Set(key string, val interface{}) *Fields {
+	f.keyvals = append(f.keyvals, key, val)
+	return f
+}
+
+// SetString adds the string value val under key, and returns f.
+func (f *Fields) SetString(key, val string) *Fields {
+	return f.Set(key, val)
+}
+
+// SetInt adds the integer value val under key, and returns f.
+func (f *Fields) SetInt(key string, val int) *Fields {
+	return f.Set(key, val)
+}
+
+// SetError adds err under key, and returns f. A nil error is added as is.
+func (f *Fields) SetError(key string, err error) *Fields {
+	return f.Set(key, err)
+}
+
+// Build returns the key-value pairs, in the order they were set. It returns a
+// copy, so the builder can still be modified and reused.
+func (f *Fields) Build() []interface{} {
+	keyvals := make([]interface{}, len(f.keyvals))
+	copy(keyvals, f.keyvals)
+	return keyvals
+}
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	f := NewFields().
+		SetString("user", "alice").
+		SetInt("attempt", 3).
+		SetError("err", errors.New("denied")).
+		Set("admin", false)
+	require.Equal(t, []interface{}{"user", "alice", "attempt", 3, "err", errors.New("denied"), "admin", false}, f.Build())
+
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.Error("login failed", f.Build()...)
+	require.Equal(t, "ERRO login failed user=alice attempt=3 err=denied admin=false\n", buf.String())
+}
+
+func TestFields_reuse(t *testing.T) {
+	var f Fields
+	require.Empty(t, f.Build())
+
+	f.SetString("a", "1")
+	kvs := f.Build()
+	f.SetString("b", "2")
+	kvs[1] = "changed"
+	require.Equal(t, []interface{}{"a", "1", "b", "2"}, f.Build())
+}
+
+func TestWithField(t *testing.T) {
+	cases := []struct {
+		name     string
+		logger   func(l *Logger) *Logger
+		expected string
+	}{
+		{"field", func(l *Logger) *Logger { return l.WithField("user", []string{"a"}) }, "INFO hello user=[a]\n"},
+		{"string", func(l *Logger) *Logger { return l.WithString("user", "alice") }, "INFO hello user=alice\n"},
+		{"int", func(l *Logger) *Logger { return l.WithInt("attempt", 3) }, "INFO hello attempt=3\n"},
+		{"float64", func(l *Logger) *Logger { return l.WithFloat64("ratio", 0.5) }, "INFO hello ratio=0.5\n"},
+		{"bool", func(l *Logger) *Logger { return l.WithBool("admin", true) }, "INFO hello admin=true\n"},
+		{"error", func(l *Logger) *Logger { return l.WithError(errors.New("denied")) }, "INFO hello err=denied\n"},
+		{"chained", func(l *Logger) *Logger { return l.WithString("user", "alice").WithInt("attempt", 3) }, "INFO hello user=alice attempt=3\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf)
+			c.logger(l).Info("hello")
+			l.Info("parent")
+			require.Equal(t, c.expected+"INFO parent\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+)
+
+// WithFileRouter returns a logger option that writes the entries to one file
+// per group of levels, instead of the logger output. Each route's file
+// receives the entries from its level up to the level of the next route:
+//
+//	log.WithFileRouter(map[log.Level]string{
+//		log.DebugLevel: "debug.log", // Debug, Info, and Warn entries
+//		log.ErrorLevel: "error.log", // Error and Fatal entries
+//	})
+//
+// Entries below the lowest route level, and entries without a level, are
+// written to the file of the lowest route. Files are opened in append mode,
+// and created if needed. Levels can share a file. A route whose file can't be
+// opened writes to the previous output of the logger instead, and the error
+// is logged there. Close the logger to close the files.
+func WithFileRouter(routes map[Level]string) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(routes) == 0 {
+			return
+		}
+
+		r := &fileRouter{}
+		files := make(map[string]*os.File, len(routes))
+		for level, path := range routes {
+			route := fileRoute{level: level, w: l.w}
+			f, ok := files[path]
+			if !ok {
+				var err error
+				f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+				if err != nil {
+					New(l.w).Error("can't open log file", "path", path, "err", err)
+				} else {
+					files[path] = f
+					r.files = append(r.files, f)
+				}
+			}
+			if f != nil {
+				route.w = f
+			}
+			r.routes = append(r.routes, route)
+		}
+		sort.Slice(r.routes, func(i, j int) bool {
+			return r.routes[i].level < r.routes[j].level
+		})
+
+		l.w = r
+		l.re = l.renderer(r)
+		atomic.StoreUint32(&l.isDiscard, 0)
+	}
+}
+
+// fileRoute is a route of a fileRouter.
+type fileRoute struct {
+	level Level
+	w     io.Writer
+}
+
+// fileRouter writes entries to the writer of the route of their level.
+type fileRouter struct {
+	routes []fileRoute // sorted by level
+	files  []*os.File
+}
+
+// Write implements io.Writer. p is written to the lowest route.
+func (r *fileRouter) Write(p []byte) (int, error) {
+	return r.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p to the route of level.
+func (r *fileRouter) WriteLevel(level Level, p []byte) (int, error) {
+	route := r.routes[0]
+	if level != noLevel {
+		for _, rt := range r.routes[1:] {
+			if rt.level > level {
+				break
+			}
+			route = rt
+		}
+	}
+	return writeLevel(route.w, level, p)
+}
+
+// Close closes the files, and returns the first error.
+func (r *fileRouter) Close() error {
+	var err error
+	for _, f := range r.files {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFileRouter(t *testing.T) {
+	dir := t.TempDir()
+	debug := filepath.Join(dir, "debug.log")
+	errPath := filepath.Join(dir, "error.log")
+	require.NoError(t, os.WriteFile(debug, []byte("previous\n"), 0o600))
+
+	l := New(nil, WithFileRouter(map[Level]string{
+		InfoLevel:  debug,
+		ErrorLevel: errPath,
+	}))
+	l.SetLevel(DebugLevel)
+	l.Debug("debug")
+	l.Info("info")
+	l.With("foo", "bar").Warn("warn")
+	l.Error("error")
+	l.Print("print")
+	require.NoError(t, l.Close())
+
+	assertFile(t, debug, "previous\nDEBU debug\nINFO info\nWARN warn foo=bar\nprint\n")
+	assertFile(t, errPath, "ERRO error\n")
+}
+
+func TestWithFileRouter_sharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	l := New(nil, WithFileRouter(map[Level]string{
+		DebugLevel: path,
+		WarnLevel:  path,
+	}))
+	l.Info("info")
+	l.Warn("warn")
+	require.NoError(t, l.Close())
+	assertFile(t, path, "INFO info\nWARN warn\n")
+}
+
+func TestWithFileRouter_openError(t *testing.T) {
+	var buf bytes.Buffer
+	path := filepath.Join(t.TempDir(), "missing", "error.log")
+	l := New(&buf, WithFileRouter(map[Level]string{ErrorLevel: path}))
+	l.Error("error")
+	require.Contains(t, buf.String(), "ERRO can't open log file path="+path)
+	require.Contains(t, buf.String(), "\nERRO error\n")
+}
+
+func assertFile(t *testing.T, path, expected string) {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(b))
+}
+
+func TestWithFileRouter_wrapped(t *testing.T) {
+	dir := t.TempDir()
+	debug := filepath.Join(dir, "debug.log")
+	errPath := filepath.Join(dir, "error.log")
+	l := New(nil, WithFileRouter(map[Level]string{
+		DebugLevel: debug,
+		ErrorLevel: errPath,
+	})).WithCircuitBreaker(1, time.Second)
+	l.Info("info")
+	l.Error("error")
+	require.NoError(t, l.Close())
+	assertFile(t, debug, "INFO info\n")
+	assertFile(t, errPath, "ERRO error\n")
+}
@@ -0,0 +1,46 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// autoFlusher periodically flushes a logger.
+type autoFlusher struct {
+	done chan struct{}
+	once sync.Once
+}
+
+func (f *autoFlusher) run(l *Logger, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			_ = l.Flush()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+func (f *autoFlusher) stop() {
+	f.once.Do(func() {
+		close(f.done)
+	})
+}
+
+// WithAutoFlush returns a logger option that flushes the output every
+// interval. This makes sure buffered entries reach the output during periods
+// of low traffic. Flushing stops when the logger is closed. Loggers derived
+// with Logger.With don't stop it when closed.
+func WithAutoFlush(interval time.Duration) LoggerOption {
+	return func(l *Logger) {
+		if l.autoFlush != nil {
+			l.autoFlush.stop()
+		}
+		f := &autoFlusher{done: make(chan struct{})}
+		l.autoFlush = f
+		go f.run(l, interval)
+	}
+}
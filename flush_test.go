@@ -0,0 +1,72 @@
+package log
+
+import (
+	"bufio"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a buffer that's safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf closeBuffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFlush(t *testing.T) {
+	var buf closeBuffer
+	l := New(bufio.NewWriter(&buf))
+	l.Info("info")
+	require.Equal(t, "", buf.String())
+	require.NoError(t, l.Flush())
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+func TestAutoFlush(t *testing.T) {
+	var buf syncBuffer
+	l := New(bufio.NewWriter(&buf), WithAutoFlush(10*time.Millisecond))
+	l.Info("info")
+	require.Eventually(t, func() bool {
+		return buf.String() == "INFO info\n"
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, l.Close())
+	select {
+	case <-l.autoFlush.done:
+	default:
+		t.Fatal("auto flush wasn't stopped")
+	}
+}
+
+func TestAutoFlush_with(t *testing.T) {
+	l := New(nil, WithAutoFlush(time.Hour))
+	defer l.Close()
+	sub := l.With("key", "value")
+	require.Nil(t, sub.autoFlush)
+	require.NoError(t, sub.Close())
+	select {
+	case <-l.autoFlush.done:
+		t.Fatal("closing a derived logger stopped auto flush")
+	default:
+	}
+}
+
+func TestClose(t *testing.T) {
+	var buf closeBuffer
+	l := New(&buf)
+	require.NoError(t, l.Close())
+	require.True(t, buf.closed)
+}
@@ -6,6 +6,7 @@ require (
 	github.com/charmbracelet/lipgloss v0.7.1
 	github.com/go-logfmt/logfmt v0.6.0
 	github.com/muesli/termenv v0.15.1
+	github.com/petermattis/goid v0.0.0-20260918085751-abfca077860b
 	github.com/stretchr/testify v1.8.2
 )
 
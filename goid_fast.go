@@ -0,0 +1,11 @@
+//go:build goid
+// +build goid
+
+package log
+
+import "github.com/petermattis/goid"
+
+// goroutineID returns the ID of the current goroutine.
+func goroutineID() uint64 {
+	return uint64(goid.Get())
+}
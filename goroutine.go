@@ -0,0 +1,18 @@
+package log
+
+// GoroutineKey is the key of the goroutine ID field.
+var GoroutineKey = "goroutine"
+
+// WithGoroutineID returns a logger option that appends a "goroutine" field to
+// every entry with the ID of the logging goroutine.
+//
+// By default, the ID is read from the goroutine stack trace, which is slow.
+// Build with the "goid" tag to use a faster, but less portable,
+// implementation.
+func WithGoroutineID() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.reportGoroutine = true
+	}
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineID(t *testing.T) {
+	ids := make(map[uint64]struct{})
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := goroutineID()
+			require.Equal(t, id, goroutineID())
+			mu.Lock()
+			ids[id] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, ids, 10)
+	require.NotZero(t, goroutineID())
+}
+
+func TestWithGoroutineID(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithGoroutineID())
+	l.Info("info", "foo", "bar")
+	require.Equal(t, fmt.Sprintf("INFO info foo=bar goroutine=%d\n", goroutineID()), buf.String())
+}
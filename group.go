@@ -0,0 +1,38 @@
+package log
+
+import "fmt"
+
+// WithGroup returns a new logger that qualifies the keys of the fields added
+// afterwards, both with With and when logging, with name and a dot, like
+// slog groups:
+//
+//	logger.WithGroup("http").With("status", 200).Info("done", "bytes", 42)
+//	// INFO done http.status=200 http.bytes=42
+//
+// Groups nest, so WithGroup("http").WithGroup("req") qualifies keys with
+// "http.req.". The fields of the logger, and the fields extracted from its
+// context, are left as is. An empty name returns l.
+func (l *Logger) WithGroup(name string) *Logger {
+	if name == "" {
+		return l
+	}
+	sl := l.With()
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.group += name + "."
+	return sl
+}
+
+// appendGroup appends keyvals to dst, qualifying their keys with group.
+func appendGroup(dst []interface{}, group string, keyvals []interface{}) []interface{} {
+	if group == "" {
+		return append(dst, keyvals...)
+	}
+	for i, kv := range keyvals {
+		if i%2 == 0 {
+			kv = group + fmt.Sprint(kv)
+		}
+		dst = append(dst, kv)
+	}
+	return dst
+}
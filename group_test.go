@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithGroup(t *testing.T) {
+	cases := []struct {
+		name     string
+		logger   func(l *Logger) *Logger
+		keyvals  []interface{}
+		expected string
+	}{
+		{
+			name:     "with fields",
+			logger:   func(l *Logger) *Logger { return l.WithGroup("http").With("status", 200) },
+			expected: "INFO done http.status=200\n",
+		},
+		{
+			name:     "entry fields",
+			logger:   func(l *Logger) *Logger { return l.WithGroup("http") },
+			keyvals:  []interface{}{"bytes", 42},
+			expected: "INFO done http.bytes=42\n",
+		},
+		{
+			name:     "fields before group",
+			logger:   func(l *Logger) *Logger { return l.With("app", "api").WithGroup("http").With("method", "GET") },
+			keyvals:  []interface{}{"status", 200},
+			expected: "INFO done app=api http.method=GET http.status=200\n",
+		},
+		{
+			name:     "nested",
+			logger:   func(l *Logger) *Logger { return l.WithGroup("http").WithGroup("req") },
+			keyvals:  []interface{}{"id", 1},
+			expected: "INFO done http.req.id=1\n",
+		},
+		{
+			name:     "empty name",
+			logger:   func(l *Logger) *Logger { return l.WithGroup("") },
+			keyvals:  []interface{}{"id", 1},
+			expected: "INFO done id=1\n",
+		},
+		{
+			name:     "missing value",
+			logger:   func(l *Logger) *Logger { return l.WithGroup("http") },
+			keyvals:  []interface{}{"status"},
+			expected: "INFO done http.status=\"missing value\"\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c.logger(New(&buf)).Info("done", c.keyvals...)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWithGroup_parent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.WithGroup("http")
+	l.Info("done", "status", 200)
+	require.Equal(t, "INFO done status=200\n", buf.String())
+}
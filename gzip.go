@@ -0,0 +1,93 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+)
+
+// NewGzipWriter returns a writer that compresses the entries written to it
+// with gzip before writing them to w, like for log files meant to be
+// archived:
+//
+//	f, err := os.OpenFile("app.log.gz", os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+//	...
+//	gz, err := log.NewGzipWriter(f)
+//	...
+//	logger := log.New(gz)
+//	defer logger.Close()
+//
+// Compressed data is buffered: flush the writer, or the logger, to write the
+// pending entries to w. Closing the writer flushes the gzip stream, writes
+// its footer, and closes w if it supports closing, unless it's the standard
+// output or error stream. Appending to an existing gzip file starts a new
+// stream, which OpenGzipLog reads transparently.
+func NewGzipWriter(w io.Writer) (io.WriteCloser, error) {
+	zw, err := gzip.NewWriterLevel(w, gzip.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipWriter{w: w, zw: zw}, nil
+}
+
+// OpenGzipLog opens the gzip-compressed log file at path, and returns the file
+// along with a reader of its decompressed content. Close the file once done
+// reading.
+func OpenGzipLog(path string) (*os.File, *gzip.Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	return f, zr, nil
+}
+
+// gzipWriter compresses the data written to it with zw, which writes to w.
+type gzipWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	zw     *gzip.Writer
+	closed bool
+}
+
+// Write implements io.Writer.
+func (g *gzipWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return 0, ErrWriterClosed
+	}
+	return g.zw.Write(p)
+}
+
+// Flush writes the pending compressed data to the underlying writer.
+func (g *gzipWriter) Flush() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return nil
+	}
+	if err := g.zw.Flush(); err != nil {
+		return err
+	}
+	return flushWriter(g.w)
+}
+
+// Close ends the gzip stream, and closes the underlying writer.
+func (g *gzipWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+	if err := g.zw.Close(); err != nil {
+		return err
+	}
+	return closeWriter(g.w)
+}
@@ -0,0 +1,70 @@
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGzipWriter(t *testing.T) {
+	var buf bytes.Buffer
+	gz, err := NewGzipWriter(&buf)
+	require.NoError(t, err)
+	l := New(gz)
+	l.Info("hello", "foo", "bar")
+	l.Warn("bye")
+
+	require.NoError(t, l.Flush())
+	require.NotZero(t, buf.Len())
+	require.NoError(t, l.Close())
+	require.NoError(t, gz.Close())
+
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	b, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, "INFO hello foo=bar\nWARN bye\n", string(b))
+
+	_, err = gz.Write([]byte("late\n"))
+	require.ErrorIs(t, err, ErrWriterClosed)
+}
+
+func TestOpenGzipLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	for _, msg := range []string{"first", "second"} {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		require.NoError(t, err)
+		gz, err := NewGzipWriter(f)
+		require.NoError(t, err)
+		l := New(gz)
+		l.Info(msg)
+		require.NoError(t, l.Close())
+		// The file is closed along with the gzip stream.
+		require.ErrorIs(t, f.Close(), os.ErrClosed)
+	}
+
+	f, zr, err := OpenGzipLog(path)
+	require.NoError(t, err)
+	defer f.Close()
+	entries, err := Parse(zr)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "first", entries[0].Message)
+	require.Equal(t, "second", entries[1].Message)
+}
+
+func TestOpenGzipLog_errors(t *testing.T) {
+	dir := t.TempDir()
+	_, _, err := OpenGzipLog(filepath.Join(dir, "missing.gz"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(dir, "plain.log")
+	require.NoError(t, os.WriteFile(path, []byte("INFO hello\n"), 0o600))
+	_, _, err = OpenGzipLog(path)
+	require.ErrorIs(t, err, gzip.ErrHeader)
+}
@@ -0,0 +1,27 @@
+package log
+
+import "sync/atomic"
+
+// WithInheritLevel returns a logger option that makes a child logger, created
+// with With or WithOptions, follow the level of its parent. The child logs
+// at whatever level its parent is set to, including changes made with
+// SetLevel after the child was created. Loggers derived from the child
+// inherit its level in turn. Setting the level of the child itself stops the
+// inheritance. The option has no effect on loggers without a parent.
+//
+//	child := logger.WithOptions(log.WithInheritLevel())
+//	logger.SetLevel(log.DebugLevel) // child now logs debug entries
+func WithInheritLevel() LoggerOption {
+	return func(l *Logger) {
+		atomic.StoreUint32(&l.inheritLevel, 1)
+	}
+}
+
+// effectiveLevel returns the level of the logger, following inherited levels
+// up to the first logger that doesn't inherit its level.
+func (l *Logger) effectiveLevel() int32 {
+	for l.parent != nil && atomic.LoadUint32(&l.inheritLevel) != 0 {
+		l = l.parent
+	}
+	return atomic.LoadInt32(&l.level)
+}
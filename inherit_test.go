@@ -0,0 +1,40 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritLevel(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(&buf)
+	child := parent.WithOptions(WithInheritLevel()).With("child", true)
+	grandchild := child.With()
+	static := parent.With("static", true)
+
+	child.Debug("before")
+	require.Empty(t, buf.String())
+
+	parent.SetLevel(DebugLevel)
+	require.Equal(t, DebugLevel, child.GetLevel())
+	child.Debug("inherited")
+	grandchild.Debug("inherited")
+	static.Debug("static")
+	require.Equal(t, "DEBU inherited child=true\nDEBU inherited child=true\n", buf.String())
+
+	buf.Reset()
+	child.SetLevel(ErrorLevel)
+	parent.SetLevel(DebugLevel)
+	child.Info("own level")
+	grandchild.Info("own level")
+	require.Empty(t, buf.String())
+}
+
+func TestInheritLevel_noParent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithInheritLevel())
+	l.Info("info")
+	require.Equal(t, "INFO info\n", buf.String())
+}
@@ -0,0 +1,40 @@
+package log
+
+import "math"
+
+// DropLevel can be returned by an interceptor to drop an entry.
+const DropLevel = Level(math.MinInt32)
+
+// Interceptor transforms the level, message, and key-value pairs of an entry.
+type Interceptor = func(level Level, msg string, keyvals []interface{}) (Level, string, []interface{})
+
+// interceptor adapts an Interceptor to a Transformer.
+type interceptor struct {
+	fn Interceptor
+}
+
+// Transform implements Transformer.
+func (i interceptor) Transform(e *LogEntry) *LogEntry {
+	level, msg, keyvals := i.fn(e.Level, e.Message, e.Fields)
+	if level == DropLevel {
+		return nil
+	}
+	e.Level, e.Message, e.Fields = level, msg, keyvals
+	return e
+}
+
+// Intercept returns a new logger that calls fn for every entry before it's
+// formatted. fn receives the level, message, and key-value pairs, including
+// the logger fields, and returns their replacements. Returning DropLevel
+// drops the entry.
+//
+// Interceptors run after the transformers of the logger pipeline, if any.
+func (l *Logger) Intercept(fn Interceptor) *Logger {
+	sl := l.With()
+	var transformers []Transformer
+	if l.pipeline != nil {
+		transformers = append(transformers, l.pipeline.transformers...)
+	}
+	sl.pipeline = NewPipeline(append(transformers, interceptor{fn})...)
+	return sl
+}
@@ -0,0 +1,96 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntercept(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(&buf).With("foo", "bar")
+	l := parent.Intercept(func(level Level, msg string, keyvals []interface{}) (Level, string, []interface{}) {
+		if strings.HasPrefix(msg, "noisy") {
+			return DropLevel, "", nil
+		}
+		if level == InfoLevel && strings.Contains(msg, "fail") {
+			level = ErrorLevel
+		}
+		return level, strings.ToUpper(msg), append(keyvals, "intercepted", true)
+	})
+	cases := []struct {
+		name     string
+		expected string
+		f        func()
+	}{
+		{
+			name:     "transform",
+			expected: "INFO HELLO foo=bar intercepted=true\n",
+			f:        func() { l.Info("hello") },
+		},
+		{
+			name:     "change level",
+			expected: "ERRO FAILED foo=bar baz=1 intercepted=true\n",
+			f:        func() { l.Info("failed", "baz", 1) },
+		},
+		{
+			name:     "drop",
+			expected: "",
+			f:        func() { l.Info("noisy message") },
+		},
+		{
+			name:     "debug is not dropped",
+			expected: "DEBU DEBUG foo=bar intercepted=true\n",
+			f: func() {
+				l.SetLevel(DebugLevel)
+				defer l.SetLevel(InfoLevel)
+				l.Debug("debug")
+			},
+		},
+		{
+			name:     "odd keyvals",
+			expected: "INFO ODD foo=bar odd=\"missing value\" intercepted=true\n",
+			f:        func() { l.Info("odd", "odd") },
+		},
+		{
+			name:     "parent",
+			expected: "INFO hello foo=bar\n",
+			f:        func() { parent.Info("hello") },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			c.f()
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestIntercept_chain(t *testing.T) {
+	var buf bytes.Buffer
+	suffix := func(s string) Interceptor {
+		return func(level Level, msg string, keyvals []interface{}) (Level, string, []interface{}) {
+			return level, msg + s, keyvals
+		}
+	}
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		e.Message += "!"
+		return e
+	}))
+	l := New(&buf, WithPipeline(p)).Intercept(suffix("a")).Intercept(suffix("b"))
+	l.Info("info")
+	require.Equal(t, "INFO info!ab\n", buf.String())
+	require.Len(t, p.transformers, 1)
+}
+
+func TestIntercept_oddKeyvals(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).Intercept(func(level Level, msg string, keyvals []interface{}) (Level, string, []interface{}) {
+		return level, msg, append(keyvals, "odd")
+	})
+	l.Info("info")
+	require.Equal(t, "INFO info odd=\"missing value\"\n", buf.String())
+}
@@ -1,12 +1,14 @@
 package log
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
-func (l *Logger) jsonFormatter(keyvals ...interface{}) {
+func (l *Logger) jsonFormatter(b *bytes.Buffer, keyvals ...interface{}) {
 	m := make(map[string]interface{}, len(keyvals)/2)
 	for i := 0; i < len(keyvals); i += 2 {
 		switch keyvals[i] {
@@ -55,7 +57,34 @@ func (l *Logger) jsonFormatter(keyvals ...interface{}) {
 		}
 	}
 
-	e := json.NewEncoder(&l.b)
+	e := json.NewEncoder(b)
 	e.SetEscapeHTML(false)
 	_ = e.Encode(m)
 }
+
+// WriteJSON logs v, marshaled to JSON, as the message of an entry at level,
+// with no key-value pairs other than the logger fields. It's useful to log a
+// complete object as is. If v can't be marshaled, it's formatted with
+// fmt.Sprintf("%+v", v) instead, and the marshal error is returned. Writing
+// at FatalLevel exits the program, like Fatal.
+func (l *Logger) WriteJSON(level Level, v interface{}) error {
+	if level != FatalLevel && l.skipFormat(level) {
+		return nil
+	}
+
+	var msg string
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	err := e.Encode(v)
+	if err != nil {
+		msg = fmt.Sprintf("%+v", v)
+	} else {
+		msg = strings.TrimSuffix(b.String(), "\n")
+	}
+	l.log(level, msg)
+	if level == FatalLevel {
+		l.exit(msg)
+	}
+	return err
+}
@@ -179,3 +179,30 @@ func TestJsonCustomKey(t *testing.T) {
 	logger.Info("info")
 	require.Equal(t, "{\"lvl\":\"info\",\"msg\":\"info\",\"time\":\"0001/01/01 00:00:00\"}\n", buf.String())
 }
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).With("app", "test")
+	type user struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	require.NoError(t, l.WriteJSON(InfoLevel, user{Name: "<foo>", Tags: []string{"a"}}))
+	require.Equal(t, `INFO {"name":"<foo>","tags":["a"]} app=test`+"\n", buf.String())
+
+	buf.Reset()
+	l.SetFormatter(JSONFormatter)
+	require.NoError(t, l.WriteJSON(WarnLevel, map[string]int{"n": 1}))
+	require.Equal(t, `{"app":"test","lvl":"warn","msg":"{\"n\":1}"}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, l.WriteJSON(DebugLevel, map[string]int{"n": 1}))
+	require.Empty(t, buf.String())
+
+	buf.Reset()
+	l.SetFormatter(TextFormatter)
+	err := l.WriteJSON(ErrorLevel, struct{ C chan int }{})
+	require.Error(t, err)
+	require.Equal(t, "ERRO {C:<nil>} app=test\n", buf.String())
+}
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// WithLatencyBudget returns a logger option that detects slow writes. When
+// writing a single entry takes longer than budget, a warning with the
+// duration of the write is logged to os.Stderr, from a separate goroutine so
+// that logging isn't slowed down further. This helps finding out when the
+// writer of the logger becomes a bottleneck.
+func WithLatencyBudget(budget time.Duration) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.latencyBudget = budget
+		if l.slowWriteOutput == nil {
+			l.slowWriteOutput = os.Stderr
+		}
+	}
+}
+
+// warnSlowWrite logs a warning about a write that took d, over budget, to w.
+func warnSlowWrite(w io.Writer, d, budget time.Duration) {
+	New(w).Warn("slow log write", "duration", d, "budget", budget)
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// slowWriter is a writer that takes delay to write.
+type slowWriter struct {
+	bytes.Buffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.Buffer.Write(p)
+}
+
+// chanWriter is a writer that sends what's written to a channel.
+type chanWriter chan string
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+func TestWithLatencyBudget(t *testing.T) {
+	w := &slowWriter{delay: 20 * time.Millisecond}
+	warnings := make(chanWriter, 1)
+	l := New(w, WithLatencyBudget(5*time.Millisecond))
+	require.NotNil(t, l.slowWriteOutput)
+	l.slowWriteOutput = warnings
+
+	l.Info("hello")
+	require.Equal(t, "INFO hello\n", w.String())
+	select {
+	case warning := <-warnings:
+		require.Regexp(t, `^WARN slow log write duration=\S+ budget=5ms\n$`, warning)
+	case <-time.After(time.Second):
+		t.Fatal("no warning about the slow write")
+	}
+
+	w.delay = 0
+	l.Info("fast")
+	select {
+	case warning := <-warnings:
+		t.Fatalf("unexpected warning: %q", warning)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
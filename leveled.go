@@ -0,0 +1,63 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// LeveledWriter is a writer that logs each line written to it as the message
+// of an entry at a given level. It lets libraries that only accept an
+// io.Writer, like http.Server.ErrorLog through log.New, log through a
+// Logger.
+type LeveledWriter struct {
+	mu    sync.Mutex
+	l     *Logger
+	level Level
+	buf   []byte
+}
+
+// NewLeveledWriter returns a writer that logs each line written to it with l
+// at level. Empty lines are skipped, and an incomplete line is held until
+// it's completed by a newline or flushed with Flush.
+func NewLeveledWriter(l *Logger, level Level) io.Writer {
+	return &LeveledWriter{l: l, level: level}
+}
+
+// Write implements io.Writer.
+func (w *LeveledWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+	return len(p), nil
+}
+
+// Flush logs the incomplete line held by the writer, if any.
+func (w *LeveledWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.logLine(w.buf)
+	w.buf = nil
+	return nil
+}
+
+// logLine logs a line, without its trailing carriage return. It must be
+// called with the lock held.
+func (w *LeveledWriter) logLine(line []byte) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return
+	}
+	w.l.log(w.level, string(line))
+}
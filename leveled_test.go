@@ -0,0 +1,43 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	stdlog "log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeveledWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).With("component", "http")
+	w := NewLeveledWriter(l, ErrorLevel)
+
+	_, err := fmt.Fprint(w, "first line\r\nsecond ")
+	require.NoError(t, err)
+	require.Equal(t, "ERRO first line component=http\n", buf.String())
+
+	buf.Reset()
+	_, err = fmt.Fprint(w, "line\n\nthird")
+	require.NoError(t, err)
+	require.Equal(t, "ERRO second line component=http\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, w.(*LeveledWriter).Flush())
+	require.NoError(t, w.(*LeveledWriter).Flush())
+	require.Equal(t, "ERRO third component=http\n", buf.String())
+}
+
+func TestLeveledWriter_stdlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(WarnLevel)
+	std := stdlog.New(NewLeveledWriter(l, WarnLevel), "", 0)
+	std.Printf("http: TLS handshake error from %s", "1.2.3.4")
+	require.Equal(t, "WARN http: TLS handshake error from 1.2.3.4\n", buf.String())
+
+	buf.Reset()
+	stdlog.New(NewLeveledWriter(l, InfoLevel), "", 0).Print("filtered")
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,210 @@
+// Package logcloudwatch provides a writer that puts log entries into AWS
+// CloudWatch Logs.
+package logcloudwatch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// PutLogEvents limits, see
+// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutLogEvents.html
+const (
+	maxBatchBytes  = 1048576
+	maxBatchEvents = 10000
+	eventOverhead  = 26
+	// minPutInterval keeps requests under 5 per second per stream.
+	minPutInterval = time.Second / 5
+	flushInterval  = time.Second
+)
+
+// client is the part of *cloudwatchlogs.Client used by the writer.
+type client interface {
+	PutLogEvents(ctx context.Context, in *cloudwatchlogs.PutLogEventsInput, opts ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error)
+	CreateLogGroup(ctx context.Context, in *cloudwatchlogs.CreateLogGroupInput, opts ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error)
+	CreateLogStream(ctx context.Context, in *cloudwatchlogs.CreateLogStreamInput, opts ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error)
+}
+
+// writer puts log entries into a CloudWatch Logs stream in batches.
+type writer struct {
+	mu      sync.Mutex
+	svc     client
+	group   string
+	stream  string
+	token   *string
+	events  []types.InputLogEvent
+	size    int
+	lastPut time.Time
+	err     error
+	closed  bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+	now     func() time.Time
+}
+
+// NewCloudWatchWriter returns a writer that puts each formatted log entry as
+// an event into the stream of the log group. The log group and stream must
+// exist, see CreateCloudWatchWriter otherwise.
+//
+// Events are sent in batches every second, or as soon as a batch reaches the
+// PutLogEvents size limits, at most 5 times per second. Close the writer to
+// send the pending events.
+func NewCloudWatchWriter(svc *cloudwatchlogs.Client, group, stream string) io.WriteCloser {
+	return newWriter(svc, group, stream)
+}
+
+// CreateCloudWatchWriter is like NewCloudWatchWriter, but creates the log
+// group and stream first if they don't exist.
+func CreateCloudWatchWriter(ctx context.Context, svc *cloudwatchlogs.Client, group, stream string) (io.WriteCloser, error) {
+	if err := createStream(ctx, svc, group, stream); err != nil {
+		return nil, err
+	}
+	return newWriter(svc, group, stream), nil
+}
+
+// createStream creates the log group and stream if they don't exist.
+func createStream(ctx context.Context, svc client, group, stream string) error {
+	var exists *types.ResourceAlreadyExistsException
+	_, err := svc.CreateLogGroup(ctx, &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(group),
+	})
+	if err != nil && !errors.As(err, &exists) {
+		return err
+	}
+	_, err = svc.CreateLogStream(ctx, &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(group),
+		LogStreamName: aws.String(stream),
+	})
+	if err != nil && !errors.As(err, &exists) {
+		return err
+	}
+	return nil
+}
+
+func newWriter(svc client, group, stream string) *writer {
+	w := &writer{
+		svc:    svc,
+		group:  group,
+		stream: stream,
+		done:   make(chan struct{}),
+		now:    time.Now,
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+func (w *writer) run() {
+	defer w.wg.Done()
+	t := time.NewTicker(flushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			w.mu.Lock()
+			w.err = w.flush()
+			w.mu.Unlock()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// Write implements io.Writer. The error of the last failed batch, if any, is
+// returned.
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	msg := strings.TrimRight(string(p), "\n")
+	size := len(msg) + eventOverhead
+	if w.size+size > maxBatchBytes || len(w.events) >= maxBatchEvents {
+		w.err = w.flush()
+	}
+	w.events = append(w.events, types.InputLogEvent{
+		Message:   aws.String(msg),
+		Timestamp: aws.Int64(w.now().UnixMilli()),
+	})
+	w.size += size
+
+	if err := w.err; err != nil {
+		w.err = nil
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+// Flush sends the pending events.
+func (w *writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+// Close sends the pending events, and stops accepting new ones.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+
+	w.wg.Wait()
+	return w.Flush()
+}
+
+// flush sends the pending events. It must be called with the lock held.
+func (w *writer) flush() error {
+	if len(w.events) == 0 {
+		return nil
+	}
+	if d := minPutInterval - time.Since(w.lastPut); d > 0 {
+		time.Sleep(d)
+	}
+
+	events := w.events
+	w.events, w.size = nil, 0
+	err := w.put(events)
+	w.lastPut = time.Now()
+	return err
+}
+
+// put sends events, retrying once with the expected sequence token if the
+// token was rotated.
+func (w *writer) put(events []types.InputLogEvent) error {
+	for attempt := 0; ; attempt++ {
+		out, err := w.svc.PutLogEvents(context.Background(), &cloudwatchlogs.PutLogEventsInput{
+			LogGroupName:  aws.String(w.group),
+			LogStreamName: aws.String(w.stream),
+			LogEvents:     events,
+			SequenceToken: w.token,
+		})
+		var invalid *types.InvalidSequenceTokenException
+		if errors.As(err, &invalid) && attempt == 0 {
+			w.token = invalid.ExpectedSequenceToken
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		w.token = out.NextSequenceToken
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package logcloudwatch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	mu       sync.Mutex
+	batches  [][]string
+	tokens   []string
+	expected string
+	groups   []string
+	streams  []string
+	exists   bool
+}
+
+func (c *fakeClient) PutLogEvents(_ context.Context, in *cloudwatchlogs.PutLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	token := aws.ToString(in.SequenceToken)
+	c.tokens = append(c.tokens, token)
+	if token != c.expected {
+		return nil, &types.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String(c.expected)}
+	}
+	var msgs []string
+	for _, e := range in.LogEvents {
+		msgs = append(msgs, aws.ToString(e.Message))
+	}
+	c.batches = append(c.batches, msgs)
+	c.expected = token + "+"
+	return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String(c.expected)}, nil
+}
+
+func (c *fakeClient) CreateLogGroup(_ context.Context, in *cloudwatchlogs.CreateLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	c.groups = append(c.groups, aws.ToString(in.LogGroupName))
+	if c.exists {
+		return nil, &types.ResourceAlreadyExistsException{}
+	}
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (c *fakeClient) CreateLogStream(_ context.Context, in *cloudwatchlogs.CreateLogStreamInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	c.streams = append(c.streams, aws.ToString(in.LogStreamName))
+	if c.exists {
+		return nil, &types.ResourceAlreadyExistsException{}
+	}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func TestWriter(t *testing.T) {
+	c := &fakeClient{expected: "rotated"}
+	w := newWriter(c, "group", "stream")
+	l := log.New(w)
+	l.Info("hello", "foo", "bar")
+	l.Error("failed")
+	require.NoError(t, w.Flush())
+	l.Info("again")
+	require.NoError(t, w.Close())
+
+	require.Equal(t, [][]string{{"INFO hello foo=bar", "ERRO failed"}, {"INFO again"}}, c.batches)
+	require.Equal(t, []string{"", "rotated", "rotated+"}, c.tokens)
+
+	_, err := w.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestWriter_batchSize(t *testing.T) {
+	c := &fakeClient{}
+	w := newWriter(c, "group", "stream")
+	big := strings.Repeat("x", maxBatchBytes/2)
+	for i := 0; i < 3; i++ {
+		_, err := w.Write([]byte(big))
+		require.NoError(t, err)
+	}
+	// Two events don't fit in a batch.
+	require.Len(t, c.batches, 2)
+	require.NoError(t, w.Close())
+	require.Len(t, c.batches, 3)
+}
+
+func TestWriter_interval(t *testing.T) {
+	c := &fakeClient{}
+	w := newWriter(c, "group", "stream")
+	defer w.Close()
+	_, err := w.Write([]byte("INFO tick\n"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.batches) == 1
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestCreateStream(t *testing.T) {
+	for _, exists := range []bool{false, true} {
+		c := &fakeClient{exists: exists}
+		require.NoError(t, createStream(context.Background(), c, "group", "stream"))
+		require.Equal(t, []string{"group"}, c.groups)
+		require.Equal(t, []string{"stream"}, c.streams)
+	}
+
+	err := createStream(context.Background(), &errClient{}, "group", "stream")
+	require.EqualError(t, err, "boom")
+}
+
+type errClient struct{ fakeClient }
+
+func (*errClient) CreateLogGroup(context.Context, *cloudwatchlogs.CreateLogGroupInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return nil, errors.New("boom")
+}
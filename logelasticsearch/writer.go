@@ -0,0 +1,242 @@
+// Package logelasticsearch provides a writer that indexes log entries into
+// Elasticsearch or OpenSearch.
+package logelasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// TimestampKey is the key of the entry timestamp in indexed documents.
+const TimestampKey = "@timestamp"
+
+const (
+	maxBatchDocs  = 500
+	maxBatchBytes = 5 << 20
+	flushInterval = time.Second
+)
+
+// writer indexes log entries in batches with the _bulk API.
+type writer struct {
+	mu        sync.Mutex
+	transport esapi.Transport
+	pattern   string
+	body      bytes.Buffer
+	docs      int
+	err       error
+	closed    bool
+	done      chan struct{}
+	wg        sync.WaitGroup
+	now       func() time.Time
+}
+
+// NewElasticsearchWriter returns a writer that indexes log entries into
+// Elasticsearch with the _bulk API of client, usually an
+// *elasticsearch.Client.
+//
+// The index of each entry is named after indexPattern, formatted as a time
+// layout with the timestamp of the entry, like "logs-2006-01-02" for daily
+// indices. Entries without a timestamp use the current time. Entries can be
+// written with the text or the JSON formatter, and the documents hold their
+// fields as top-level keys, along with the @timestamp, lvl, prefix, caller,
+// and msg keys.
+//
+// Documents are sent in batches every second, or as soon as 500 of them are
+// pending. Close the writer to send the pending documents.
+func NewElasticsearchWriter(client esapi.Transport, indexPattern string) io.WriteCloser {
+	return newWriter(client, indexPattern)
+}
+
+func newWriter(transport esapi.Transport, pattern string) *writer {
+	w := &writer{
+		transport: transport,
+		pattern:   pattern,
+		done:      make(chan struct{}),
+		now:       time.Now,
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+func (w *writer) run() {
+	defer w.wg.Done()
+	t := time.NewTicker(flushInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			w.mu.Lock()
+			w.err = w.flush()
+			w.mu.Unlock()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// Write implements io.Writer. The error of the last failed batch, if any, is
+// returned.
+func (w *writer) Write(p []byte) (int, error) {
+	var entries []log.LogEntry
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		entries, err = log.ParseJSON(bytes.NewReader(p))
+	} else {
+		entries, err = log.Parse(bytes.NewReader(p))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	for _, e := range entries {
+		if err := w.add(e); err != nil {
+			return 0, err
+		}
+		if w.docs >= maxBatchDocs || w.body.Len() >= maxBatchBytes {
+			w.err = w.flush()
+		}
+	}
+
+	if err := w.err; err != nil {
+		w.err = nil
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+// add appends the bulk action and document of an entry to the pending batch.
+// It must be called with the lock held.
+func (w *writer) add(e log.LogEntry) error {
+	ts := e.Time
+	if ts.IsZero() {
+		ts = w.now()
+	}
+
+	doc := make(map[string]interface{}, len(e.Fields)/2+5)
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		doc[fmt.Sprint(e.Fields[i])] = e.Fields[i+1]
+	}
+	doc[TimestampKey] = ts.Format(time.RFC3339Nano)
+	if e.Level.String() != "" {
+		doc[log.LevelKey] = e.Level.String()
+	}
+	if e.Prefix != "" {
+		doc[log.PrefixKey] = e.Prefix
+	}
+	if e.Caller != "" {
+		doc[log.CallerKey] = e.Caller
+	}
+	if e.Message != "" {
+		doc[log.MessageKey] = e.Message
+	}
+
+	action, err := json.Marshal(map[string]interface{}{
+		"index": map[string]string{"_index": ts.Format(w.pattern)},
+	})
+	if err != nil {
+		return err
+	}
+	src, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	w.body.Write(action)
+	w.body.WriteByte('\n')
+	w.body.Write(src)
+	w.body.WriteByte('\n')
+	w.docs++
+	return nil
+}
+
+// Flush sends the pending documents.
+func (w *writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+// Close sends the pending documents, and stops accepting new ones.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+
+	w.wg.Wait()
+	return w.Flush()
+}
+
+// bulkResponse is the part of a _bulk API response used by the writer.
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// flush sends the pending documents. It must be called with the lock held.
+func (w *writer) flush() error {
+	if w.docs == 0 {
+		return nil
+	}
+	body := bytes.NewReader(append([]byte(nil), w.body.Bytes()...))
+	w.body.Reset()
+	w.docs = 0
+
+	res, err := esapi.BulkRequest{Body: body}.Do(context.Background(), w.transport)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("bulk index: %s", res.String())
+	}
+
+	var br bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&br); err != nil {
+		return err
+	}
+	if !br.Errors {
+		return nil
+	}
+	failed := 0
+	var first error
+	for _, item := range br.Items {
+		for _, r := range item {
+			if r.Status < 300 {
+				continue
+			}
+			failed++
+			if first == nil {
+				first = fmt.Errorf("%s: %s", r.Error.Type, r.Error.Reason)
+			}
+		}
+	}
+	return fmt.Errorf("bulk index: %d documents failed: %w", failed, first)
+}
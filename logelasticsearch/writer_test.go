@@ -0,0 +1,86 @@
+package logelasticsearch
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransport struct {
+	mu       sync.Mutex
+	paths    []string
+	lines    []map[string]interface{}
+	response string
+}
+
+func (t *fakeTransport) Perform(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paths = append(t.paths, r.Method+" "+r.URL.Path)
+	s := bufio.NewScanner(r.Body)
+	for s.Scan() {
+		var m map[string]interface{}
+		if err := json.Unmarshal(s.Bytes(), &m); err != nil {
+			return nil, err
+		}
+		t.lines = append(t.lines, m)
+	}
+	resp := t.response
+	if resp == "" {
+		resp = `{"errors":false,"items":[]}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(resp)),
+	}, nil
+}
+
+func TestWriter(t *testing.T) {
+	tr := &fakeTransport{}
+	w := newWriter(tr, "logs-2006-01-02")
+	now := time.Date(2023, 4, 6, 0, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	l := log.NewWithOptions(w, log.Options{
+		ReportTimestamp: true,
+		TimeFunction:    func() time.Time { return ts },
+		TimeFormat:      time.RFC3339,
+		Prefix:          "api",
+	})
+	l.Info("hello", "foo", "bar")
+	log.NewWithOptions(w, log.Options{Formatter: log.JSONFormatter}).Error("failed", "code", 500)
+	require.NoError(t, w.Close())
+
+	require.Equal(t, []string{"POST /_bulk"}, tr.paths)
+	require.Equal(t, []map[string]interface{}{
+		{"index": map[string]interface{}{"_index": "logs-2023-04-05"}},
+		{"@timestamp": "2023-04-05T06:07:08Z", "lvl": "info", "prefix": "api", "msg": "hello", "foo": "bar"},
+		{"index": map[string]interface{}{"_index": "logs-2023-04-06"}},
+		{"@timestamp": "2023-04-06T00:00:00Z", "lvl": "error", "msg": "failed", "code": float64(500)},
+	}, tr.lines)
+
+	_, err := w.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestWriter_itemErrors(t *testing.T) {
+	tr := &fakeTransport{response: `{"errors":true,"items":[
+		{"index":{"status":201}},
+		{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+	]}`}
+	w := newWriter(tr, "logs")
+	_, err := w.Write([]byte("INFO one\nINFO two\n"))
+	require.NoError(t, err)
+	require.EqualError(t, w.Flush(), "bulk index: 1 documents failed: mapper_parsing_exception: failed to parse")
+	require.NoError(t, w.Close())
+}
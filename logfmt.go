@@ -1,6 +1,7 @@
 package log
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"time"
@@ -8,8 +9,8 @@ import (
 	"github.com/go-logfmt/logfmt"
 )
 
-func (l *Logger) logfmtFormatter(keyvals ...interface{}) {
-	e := logfmt.NewEncoder(&l.b)
+func (l *Logger) logfmtFormatter(b *bytes.Buffer, keyvals ...interface{}) {
+	e := logfmt.NewEncoder(b)
 
 	for i := 0; i < len(keyvals); i += 2 {
 		switch keyvals[i] {
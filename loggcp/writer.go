@@ -0,0 +1,158 @@
+// Package loggcp provides a writer that sends log entries to Google Cloud
+// Logging.
+package loggcp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/charmbracelet/log"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// MessageKey is the key of the message in the JSON payload of entries.
+const MessageKey = "message"
+
+// entryLogger is the part of *logging.Logger used by the writer.
+type entryLogger interface {
+	Log(e logging.Entry)
+	Flush() error
+}
+
+// writer sends log entries to Cloud Logging.
+type writer struct {
+	mu     sync.Mutex
+	l      entryLogger
+	closed bool
+	now    func() time.Time
+}
+
+// NewCloudLoggingWriter returns a writer that sends each formatted log entry
+// to the log logName of client, as a structured entry. Entries can be
+// written with the text or the JSON formatter.
+//
+// The level of the entry is mapped to its severity, and the caller, when
+// reported, to its source location. The message and the key-value pairs are
+// sent as the JSON payload. Entries are buffered by the client: close the
+// writer to flush them.
+func NewCloudLoggingWriter(client *logging.Client, logName string) io.WriteCloser {
+	return newWriter(client.Logger(logName))
+}
+
+func newWriter(l entryLogger) *writer {
+	return &writer{l: l, now: time.Now}
+}
+
+// Write implements io.Writer.
+func (w *writer) Write(p []byte) (int, error) {
+	var entries []log.LogEntry
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		entries, err = log.ParseJSON(bytes.NewReader(p))
+	} else {
+		entries, err = log.Parse(bytes.NewReader(p))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	for _, e := range entries {
+		w.l.Log(w.entry(e))
+	}
+	return len(p), nil
+}
+
+// Flush sends the buffered entries.
+func (w *writer) Flush() error {
+	return w.l.Flush()
+}
+
+// Close sends the buffered entries, and stops accepting new ones. It
+// doesn't close the client.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+	return w.l.Flush()
+}
+
+// entry converts a log entry into a Cloud Logging entry.
+func (w *writer) entry(e log.LogEntry) logging.Entry {
+	payload := make(map[string]interface{}, len(e.Fields)/2+2)
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		if k, ok := e.Fields[i].(string); ok {
+			payload[k] = e.Fields[i+1]
+		}
+	}
+	if e.Message != "" {
+		payload[MessageKey] = e.Message
+	}
+	if e.Prefix != "" {
+		payload[log.PrefixKey] = e.Prefix
+	}
+
+	ts := e.Time
+	if ts.IsZero() {
+		ts = w.now()
+	}
+	return logging.Entry{
+		Timestamp:      ts,
+		Severity:       severity(e.Level),
+		Payload:        payload,
+		SourceLocation: sourceLocation(e.Caller),
+	}
+}
+
+// severity maps a level to a Cloud Logging severity.
+func severity(level log.Level) logging.Severity {
+	switch level {
+	case log.DebugLevel:
+		return logging.Debug
+	case log.InfoLevel:
+		return logging.Info
+	case log.WarnLevel:
+		return logging.Warning
+	case log.ErrorLevel:
+		return logging.Error
+	case log.FatalLevel:
+		return logging.Critical
+	default:
+		return logging.Default
+	}
+}
+
+// sourceLocation parses a caller formatted like "file:line", optionally
+// followed by ":function", or returns nil if it can't be parsed.
+func sourceLocation(caller string) *loggingpb.LogEntrySourceLocation {
+	parts := strings.SplitN(caller, ":", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+	line, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil
+	}
+	loc := &loggingpb.LogEntrySourceLocation{File: parts[0], Line: line}
+	if len(parts) == 3 {
+		loc.Function = parts[2]
+	}
+	return loc
+}
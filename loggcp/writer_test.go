@@ -0,0 +1,70 @@
+package loggcp
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLogger struct {
+	entries []logging.Entry
+	flushed int
+}
+
+func (l *fakeLogger) Log(e logging.Entry) { l.entries = append(l.entries, e) }
+
+func (l *fakeLogger) Flush() error {
+	l.flushed++
+	return nil
+}
+
+func TestWriter(t *testing.T) {
+	now := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	fl := &fakeLogger{}
+	w := newWriter(fl)
+	w.now = func() time.Time { return now }
+
+	l := log.NewWithOptions(w, log.Options{
+		ReportCaller:    true,
+		CallerFormatter: log.FullCallerFormatter,
+		Level:           log.DebugLevel,
+	})
+	l.Error("failed", "err", "boom")
+	log.NewWithOptions(w, log.Options{Formatter: log.JSONFormatter, Prefix: "app"}).Warn("slow", "ms", 42)
+	require.NoError(t, w.Close())
+	require.Equal(t, 1, fl.flushed)
+
+	require.Len(t, fl.entries, 2)
+	e := fl.entries[0]
+	require.Equal(t, now, e.Timestamp)
+	require.Equal(t, logging.Error, e.Severity)
+	require.Equal(t, map[string]interface{}{"message": "failed", "err": "boom"}, e.Payload)
+	require.Equal(t, "loggcp/writer_test.go", e.SourceLocation.File)
+	require.Equal(t, "TestWriter", e.SourceLocation.Function)
+
+	e = fl.entries[1]
+	require.Equal(t, logging.Warning, e.Severity)
+	require.Equal(t, map[string]interface{}{"message": "slow", "ms": int64(42), "prefix": "app"}, e.Payload)
+	require.Nil(t, e.SourceLocation)
+
+	_, err := w.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestSourceLocation(t *testing.T) {
+	require.Equal(t, &loggingpb.LogEntrySourceLocation{File: "log/main.go", Line: 42}, sourceLocation("log/main.go:42"))
+	require.Equal(t, &loggingpb.LogEntrySourceLocation{File: "main.go", Line: 1, Function: "main"}, sourceLocation("main.go:1:main"))
+	require.Nil(t, sourceLocation(""))
+	require.Nil(t, sourceLocation("main.go:x"))
+}
+
+func TestSeverity(t *testing.T) {
+	require.Equal(t, logging.Debug, severity(log.DebugLevel))
+	require.Equal(t, logging.Info, severity(log.InfoLevel))
+	require.Equal(t, logging.Critical, severity(log.FatalLevel))
+	require.Equal(t, logging.Default, severity(log.Level(100)))
+}
@@ -2,6 +2,7 @@ package log
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
@@ -30,84 +32,330 @@ type Logger struct {
 	mu *sync.RWMutex
 	re *lipgloss.Renderer
 
-	isDiscard uint32
+	isDiscard    uint32
+	inheritLevel uint32
+	lastLog      int64
 
 	level           int32
 	prefix          string
+	category        string
+	group           string
+	msgPrefix       string
+	msgSuffix       string
+	minMsgLen       int
 	timeFunc        TimeFunction
 	timeFormat      string
-	callerOffset    int
+	callerOffset    int32
 	callerFormatter CallerFormatter
+	sourcePrefix    string
 	formatter       Formatter
 
 	reportCaller    bool
 	reportTimestamp bool
-
-	fields []interface{}
+	strictKeys      bool
+	reportElapsed   bool
+	reportDelta     bool
+	reportGoroutine bool
+	recoverPanics   bool
+	threadedCaller  bool
+	stackTraces     bool
+	panicOnCritical bool
+	panicLevel      Level
+
+	fields       []interface{}
+	deadline     time.Time
+	autoCtx      context.Context
+	ctxExtractor ContextExtractor
+	start        time.Time
 
 	helpers *sync.Map
+	timers  *sync.Map
+
+	progressLine bool
+
+	shutdown  *shutdown
+	fallback  io.Writer
+	pool      Pool
+	exports   atomic.Value
+	pipeline  *Pipeline
+	autoFlush *autoFlusher
+
+	signalFlush *SignalFlush
+
+	rateLimiter *rateLimiter
+	once        *sync.Map
+
+	parent *Logger
+
+	errLevelMapper func(error) Level
+	correlationID  func() string
+
+	elideTimestamps bool
+	elideTimestamp  bool
+	lastTimestamp   string
+
+	latencyBudget   time.Duration
+	slowWriteOutput io.Writer
+
+	counters     *levelCounters
+	events       *eventHandlers
+	quietHours   atomic.Value // *quietHours
+	callerLevels *callerLevels
+
+	expandCollections bool
+	stackOnError      bool
+	typeAnnotations   bool
+
+	sensitiveKeys []string
+
+	keyStyles   map[string]lipgloss.Style
+	valueStyles map[string]lipgloss.Style
+
+	levelStyles map[Level]lipgloss.Style
+	ansi256     bool
+
+	levelPadding bool
+	levelWidth   int32
+
+	levelEnabledHook func(level Level) bool
+	levelNotifiers   []func(old, new Level)
+	writeNotifiers   []func(level Level, n int)
 }
 
 func (l *Logger) log(level Level, msg interface{}, keyvals ...interface{}) {
-	if atomic.LoadUint32(&l.isDiscard) != 0 {
+	// Call stack is log.Error -> log.log (2)
+	l.logDepth(2, level, msg, keyvals...)
+}
+
+// logDepth logs an entry, skipping depth frames above its caller to find the
+// call site.
+func (l *Logger) logDepth(depth int, level Level, msg interface{}, keyvals ...interface{}) {
+	// Skip logDepth itself.
+	l.emit(depth+1, false, level, msg, keyvals)
+}
+
+// emit logs an entry like logDepth, as a progress entry if progress is true,
+// see Logger.Progress.
+func (l *Logger) emit(depth int, progress bool, level Level, msg interface{}, keyvals []interface{}) {
+	if dsts := l.exportDsts(); len(dsts) > 0 {
+		mirror(depth+1, l, dsts, level, msg, keyvals)
+	}
+
+	if !l.enabled(level) {
+		return
+	}
+
+	if l.callerLevels != nil && !l.callerEnabled(depth+1, level) {
 		return
 	}
 
-	// check if the level is allowed
-	if atomic.LoadInt32(&l.level) > int32(level) {
+	if l.expired() {
+		return
+	}
+
+	// A message marked as seen is forgotten again if the entry is dropped
+	// before it's written, so that it still gets its single emission.
+	var marked bool
+	if l.once != nil {
+		if l.seen(level, msg) {
+			return
+		}
+		marked = true
+		defer func() {
+			if marked {
+				l.forget(level, msg)
+			}
+		}()
+	}
+
+	var release func()
+	if l.shutdown != nil {
+		if !l.shutdown.acquire() {
+			return
+		}
+		release = l.shutdown.release
+		defer func() {
+			if release != nil {
+				release()
+			}
+		}()
+	}
+
+	var dropped int
+	if l.rateLimiter != nil {
+		var ok bool
+		if ok, dropped = l.rateLimiter.allow(time.Now()); !ok {
+			return
+		}
+	}
+
+	if l.pool != nil && level != FatalLevel {
+		// The pooled write releases the shutdown once done.
+		l.submit(depth+1, progress, dropped, level, msg, keyvals, release)
+		release = nil
+		marked = false
 		return
 	}
 
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	defer l.b.Reset()
 
-	var kvs []interface{}
-	if l.reportTimestamp {
-		kvs = append(kvs, TimestampKey, l.timeFunc())
+	if l.recoverPanics {
+		defer l.recoverPanic()
+	}
+
+	if dropped > 0 {
+		if e := l.entry(depth+1, WarnLevel, "log rate limited", RateLimitedKey, dropped); e != nil {
+			l.write(e)
+		}
+	}
+
+	if e := l.entry(depth+1, level, msg, keyvals...); e != nil {
+		marked = false
+		e.progress = progress
+		l.write(e)
+		if l.reportDelta {
+			atomic.StoreInt64(&l.lastLog, e.now.UnixNano())
+		}
+	}
+}
+
+// enabled returns true if entries at level would be written. It doesn't
+// take any lock, so that filtered calls are as cheap as possible.
+func (l *Logger) enabled(level Level) bool {
+	if atomic.LoadUint32(&l.isDiscard) != 0 {
+		return false
+	}
+	min := l.effectiveLevel()
+	if l.callerLevels != nil && int32(l.callerLevels.min) < min {
+		// The call site is checked by logDepth.
+		min = int32(l.callerLevels.min)
+	}
+	if min > int32(level) {
+		return false
+	}
+	if l.quiet(level) {
+		return false
+	}
+	return l.levelEnabledHook == nil || l.levelEnabledHook(level)
+}
+
+// write formats and writes a log entry to the output.
+func (l *Logger) write(e *LogEntry) {
+	defer l.b.Reset()
+	if l.counters != nil {
+		l.counters.add(e.Level)
+	}
+	if l.elideTimestamps && !e.Time.IsZero() {
+		ts := e.Time.Format(l.timeFormat)
+		l.elideTimestamp = ts == l.lastTimestamp
+		l.lastTimestamp = ts
+		defer func() { l.elideTimestamp = false }()
+	}
+	l.format(&l.b, e)
+	p := l.b.Bytes()
+	if e.progress || l.progressLine {
+		p = l.progressBytes(e, p)
+	}
+	var start time.Time
+	if l.latencyBudget > 0 {
+		start = time.Now()
+	}
+	n, err := writeLevel(l.w, e.Level, p)
+	if l.latencyBudget > 0 {
+		if d := time.Since(start); d > l.latencyBudget {
+			out, budget := l.slowWriteOutput, l.latencyBudget
+			l.async(func() { warnSlowWrite(out, d, budget) })
+		}
+	}
+	if err != nil {
+		if l.fallback != nil {
+			_, _ = l.fallback.Write(p)
+		}
+		return
+	}
+	for _, fn := range l.writeNotifiers {
+		fn(e.Level, n)
 	}
+}
 
-	if level != noLevel {
-		kvs = append(kvs, LevelKey, level)
+// format formats a log entry into b.
+func (l *Logger) format(b *bytes.Buffer, e *LogEntry) {
+	kvs := make([]interface{}, 0, 10+len(e.Fields))
+	if l.reportTimestamp || !e.Time.IsZero() {
+		kvs = append(kvs, TimestampKey, e.Time)
 	}
 
-	if l.reportCaller {
-		// Call stack is log.Error -> log.log (2)
-		file, line, fn := l.fillLoc(l.callerOffset + 2)
-		caller := l.callerFormatter(file, line, fn)
-		kvs = append(kvs, CallerKey, caller)
+	if e.Level != noLevel {
+		kvs = append(kvs, LevelKey, e.Level)
 	}
 
-	if l.prefix != "" {
-		kvs = append(kvs, PrefixKey, l.prefix+":")
+	if l.reportCaller || e.Caller != "" {
+		kvs = append(kvs, CallerKey, e.Caller)
 	}
 
-	if msg != nil {
-		m := fmt.Sprint(msg)
-		kvs = append(kvs, MessageKey, m)
+	if e.Prefix != "" {
+		kvs = append(kvs, PrefixKey, e.Prefix+":")
 	}
 
-	// append logger fields
-	kvs = append(kvs, l.fields...)
-	if len(l.fields)%2 != 0 {
-		kvs = append(kvs, ErrMissingValue)
+	if e.hasMessage || e.Message != "" {
+		kvs = append(kvs, MessageKey, e.Message)
 	}
-	// append the rest
-	kvs = append(kvs, keyvals...)
-	if len(keyvals)%2 != 0 {
+
+	kvs = append(kvs, e.Fields...)
+	if len(e.Fields)%2 != 0 {
 		kvs = append(kvs, ErrMissingValue)
 	}
 
 	switch l.formatter {
 	case LogfmtFormatter:
-		l.logfmtFormatter(kvs...)
+		l.logfmtFormatter(b, kvs...)
 	case JSONFormatter:
-		l.jsonFormatter(kvs...)
+		l.jsonFormatter(b, kvs...)
 	default:
-		l.textFormatter(kvs...)
+		l.textFormatter(b, kvs...)
+	}
+}
+
+// Formatted returns the formatted log entry without writing it to the
+// output. The entry is formatted regardless of the logger level. It's safe
+// for concurrent use.
+func (l *Logger) Formatted(level Level, msg string, keyvals ...interface{}) []byte {
+	// Call stack is log.Formatted -> log.formatted (2)
+	return l.formatted(2, level, msg, keyvals...)
+}
+
+func (l *Logger) formatted(skip int, level Level, msg interface{}, keyvals ...interface{}) []byte {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	e := l.entry(skip, level, msg, keyvals...)
+	if e == nil {
+		return nil
 	}
 
-	_, _ = l.w.Write(l.b.Bytes())
+	var b bytes.Buffer
+	l.format(&b, e)
+	return b.Bytes()
+}
+
+// loggerStringer formats a log line every time it's converted to a string.
+type loggerStringer struct {
+	l *Logger
+}
+
+// String implements fmt.Stringer.
+func (s loggerStringer) String() string {
+	// Call stack is log.loggerStringer.String -> log.formatted (2)
+	b := s.l.formatted(2, DebugLevel, nil)
+	return strings.TrimSuffix(string(b), "\n")
+}
+
+// Stringer returns a fmt.Stringer that formats a debug log line, with the
+// logger prefix and fields, every time it's converted to a string. Nothing is
+// written to the output.
+func (l *Logger) Stringer() fmt.Stringer {
+	return loggerStringer{l: l}
 }
 
 // Helper marks the calling function as a helper
@@ -198,18 +446,38 @@ func (l *Logger) SetReportCaller(report bool) {
 	l.reportCaller = report
 }
 
+// SetCallerDepth sets the number of additional stack frames to skip when
+// reporting the caller location. It's useful for libraries that wrap the
+// logger, so that the reported caller is the caller of the wrapper.
+func (l *Logger) SetCallerDepth(depth int) {
+	atomic.StoreInt32(&l.callerOffset, int32(depth))
+}
+
+// SetCallerOffset sets the number of additional stack frames to skip when
+// reporting the caller location.
+//
+// Deprecated: use SetCallerDepth instead.
+func (l *Logger) SetCallerOffset(n int) {
+	l.SetCallerDepth(n)
+}
+
 // GetLevel returns the current level.
 func (l *Logger) GetLevel() Level {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return Level(l.level)
+	return Level(l.effectiveLevel())
 }
 
-// SetLevel sets the current level.
+// SetLevel sets the current level. If the logger inherits the level of its
+// parent, it stops doing so.
 func (l *Logger) SetLevel(level Level) {
 	l.mu.Lock()
-	defer l.mu.Unlock()
-	atomic.StoreInt32(&l.level, int32(level))
+	atomic.StoreUint32(&l.inheritLevel, 0)
+	old := Level(atomic.SwapInt32(&l.level, int32(level)))
+	notifiers := l.levelNotifiers
+	l.mu.Unlock()
+
+	for _, fn := range notifiers {
+		fn(old, level)
+	}
 }
 
 // GetPrefix returns the current prefix.
@@ -253,13 +521,66 @@ func (l *Logger) SetOutput(w io.Writer) {
 		isDiscard = 1
 	}
 	atomic.StoreUint32(&l.isDiscard, isDiscard)
+	l.re = l.renderer(w)
+}
+
+// renderer returns the renderer of w. It must be called with the lock held.
+func (l *Logger) renderer(w io.Writer) *lipgloss.Renderer {
+	if l.ansi256 {
+		return ansi256Renderer(w)
+	}
 	// Reuse cached renderers
 	if v, ok := registry.Load(w); ok {
-		l.re = v.(*lipgloss.Renderer)
-	} else {
-		l.re = lipgloss.NewRenderer(w, termenv.WithColorCache(true))
-		registry.Store(w, l.re)
+		return v.(*lipgloss.Renderer)
+	}
+	re := lipgloss.NewRenderer(w, termenv.WithColorCache(true))
+	registry.Store(w, re)
+	return re
+}
+
+// SetWriter sets the output destination. It's an alias for SetOutput.
+func (l *Logger) SetWriter(w io.Writer) {
+	l.SetOutput(w)
+}
+
+// GetWriter returns the current output destination.
+func (l *Logger) GetWriter() io.Writer {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.w
+}
+
+// Rotate rotates the output if it supports rotation, see Rotator. It's a
+// no-op otherwise.
+func (l *Logger) Rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return rotateWriter(l.w)
+}
+
+// Flush flushes the output if it buffers writes, like bufio.Writer.
+func (l *Logger) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return flushWriter(l.w)
+}
+
+// Close stops periodic flushing and catching signals, then flushes and closes
+// the output if it supports closing. The standard output and error streams
+// are never closed.
+func (l *Logger) Close() error {
+	if l.autoFlush != nil {
+		l.autoFlush.stop()
+	}
+	if l.signalFlush != nil {
+		l.signalFlush.Cancel()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := flushWriter(l.w); err != nil {
+		return err
 	}
+	return closeWriter(l.w)
 }
 
 // SetFormatter sets the formatter.
@@ -282,10 +603,26 @@ func (l *Logger) With(keyvals ...interface{}) *Logger {
 	sl.b = bytes.Buffer{}
 	sl.mu = &sync.RWMutex{}
 	sl.helpers = &sync.Map{}
-	sl.fields = append(l.fields, keyvals...)
+	sl.timers = nil
+	sl.progressLine = false
+	sl.autoFlush = nil
+	sl.signalFlush = nil
+	sl.events = nil
+	sl.exports = atomic.Value{}
+	sl.fields = appendGroup(l.fields, l.group, keyvals)
+	sl.parent = l
 	return &sl
 }
 
+// WithOptions returns a new logger with the given options applied.
+func (l *Logger) WithOptions(opts ...LoggerOption) *Logger {
+	sl := l.With()
+	for _, opt := range opts {
+		opt(sl)
+	}
+	return sl
+}
+
 // WithPrefix returns a new logger with the given prefix.
 func (l *Logger) WithPrefix(prefix string) *Logger {
 	sl := l.With()
@@ -293,6 +630,72 @@ func (l *Logger) WithPrefix(prefix string) *Logger {
 	return sl
 }
 
+// Log prints a message at the given level. Logging at FatalLevel exits the
+// program, like Fatal.
+func (l *Logger) Log(level Level, msg interface{}, keyvals ...interface{}) {
+	l.log(level, msg, keyvals...)
+	if level == FatalLevel {
+		l.exit(msg)
+	}
+}
+
+// LogIf prints a message at the given level if cond is true, and returns
+// cond, like WarnIf. Logging at FatalLevel exits the program, like Fatal.
+func (l *Logger) LogIf(cond bool, level Level, msg interface{}, keyvals ...interface{}) bool {
+	if !cond {
+		return false
+	}
+	l.log(level, msg, keyvals...)
+	if level == FatalLevel {
+		l.exit(msg)
+	}
+	return true
+}
+
+// DebugIf prints a debug message if cond is true, and returns cond, like
+// WarnIf.
+func (l *Logger) DebugIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		l.log(DebugLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// InfoIf prints an info message if cond is true, and returns cond, like
+// WarnIf.
+func (l *Logger) InfoIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		l.log(InfoLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// WarnIf prints a warning message if cond is true. It returns cond, so that
+// it can be used as a condition:
+//
+//	if l.WarnIf(err != nil, "cleanup failed", "err", err) {
+//		// ...
+//	}
+func (l *Logger) WarnIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		l.log(WarnLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// ErrorIf prints an error message if cond is true. It returns cond, so that
+// it can be used as a condition:
+//
+//	if l.ErrorIf(err != nil, "request failed", "err", err) {
+//		return err
+//	}
+func (l *Logger) ErrorIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		l.log(ErrorLevel, msg, keyvals...)
+	}
+	return cond
+}
+
 // Debug prints a debug message.
 func (l *Logger) Debug(msg interface{}, keyvals ...interface{}) {
 	l.log(DebugLevel, msg, keyvals...)
@@ -316,7 +719,7 @@ func (l *Logger) Error(msg interface{}, keyvals ...interface{}) {
 // Fatal prints a fatal message and exits.
 func (l *Logger) Fatal(msg interface{}, keyvals ...interface{}) {
 	l.log(FatalLevel, msg, keyvals...)
-	os.Exit(1)
+	l.exit(msg)
 }
 
 // Print prints a message with no level.
@@ -326,31 +729,80 @@ func (l *Logger) Print(msg interface{}, keyvals ...interface{}) {
 
 // Debugf prints a debug message with formatting.
 func (l *Logger) Debugf(format string, args ...interface{}) {
+	if l.skipFormat(DebugLevel) {
+		return
+	}
 	l.log(DebugLevel, fmt.Sprintf(format, args...))
 }
 
 // Infof prints an info message with formatting.
 func (l *Logger) Infof(format string, args ...interface{}) {
+	if l.skipFormat(InfoLevel) {
+		return
+	}
 	l.log(InfoLevel, fmt.Sprintf(format, args...))
 }
 
 // Warnf prints a warning message with formatting.
 func (l *Logger) Warnf(format string, args ...interface{}) {
+	if l.skipFormat(WarnLevel) {
+		return
+	}
 	l.log(WarnLevel, fmt.Sprintf(format, args...))
 }
 
 // Errorf prints an error message with formatting.
 func (l *Logger) Errorf(format string, args ...interface{}) {
+	if l.skipFormat(ErrorLevel) {
+		return
+	}
 	l.log(ErrorLevel, fmt.Sprintf(format, args...))
 }
 
 // Fatalf prints a fatal message with formatting and exits.
 func (l *Logger) Fatalf(format string, args ...interface{}) {
-	l.log(FatalLevel, fmt.Sprintf(format, args...))
-	os.Exit(1)
+	msg := fmt.Sprintf(format, args...)
+	l.log(FatalLevel, msg)
+	l.exit(msg)
 }
 
 // Printf prints a message with no level and formatting.
 func (l *Logger) Printf(format string, args ...interface{}) {
 	l.log(noLevel, fmt.Sprintf(format, args...))
 }
+
+// Debugln prints a debug message made of args separated by spaces, in the
+// manner of fmt.Println.
+func (l *Logger) Debugln(args ...interface{}) {
+	if l.skipFormat(DebugLevel) {
+		return
+	}
+	l.log(DebugLevel, sprintln(args...))
+}
+
+// Infoln prints an info message made of args separated by spaces, in the
+// manner of fmt.Println.
+func (l *Logger) Infoln(args ...interface{}) {
+	if l.skipFormat(InfoLevel) {
+		return
+	}
+	l.log(InfoLevel, sprintln(args...))
+}
+
+// Warnln prints a warning message made of args separated by spaces, in the
+// manner of fmt.Println.
+func (l *Logger) Warnln(args ...interface{}) {
+	if l.skipFormat(WarnLevel) {
+		return
+	}
+	l.log(WarnLevel, sprintln(args...))
+}
+
+// Errorln prints an error message made of args separated by spaces, in the
+// manner of fmt.Println.
+func (l *Logger) Errorln(args ...interface{}) {
+	if l.skipFormat(ErrorLevel) {
+		return
+	}
+	l.log(ErrorLevel, sprintln(args...))
+}
@@ -2,6 +2,10 @@ package log
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -126,6 +130,52 @@ func TestLogFormatter(t *testing.T) {
 	}
 }
 
+func TestLogln(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(DebugLevel)
+	l.SetReportCaller(true)
+	cases := []struct {
+		name     string
+		fun      func(...interface{})
+		expected string
+	}{
+		{
+			name:     "debug",
+			fun:      l.Debugln,
+			expected: "DEBU <log/logger_test.go:%d> foo 1 true\n",
+		},
+		{
+			name:     "info",
+			fun:      l.Infoln,
+			expected: "INFO <log/logger_test.go:%d> foo 1 true\n",
+		},
+		{
+			name:     "warn",
+			fun:      l.Warnln,
+			expected: "WARN <log/logger_test.go:%d> foo 1 true\n",
+		},
+		{
+			name:     "error",
+			fun:      l.Errorln,
+			expected: "ERRO <log/logger_test.go:%d> foo 1 true\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			_, _, line, _ := runtime.Caller(0)
+			c.fun("foo", 1, true)
+			assert.Equal(t, fmt.Sprintf(c.expected, line+1), buf.String())
+		})
+	}
+
+	buf.Reset()
+	l.SetLevel(InfoLevel)
+	l.Debugln("filtered")
+	assert.Empty(t, buf.String())
+}
+
 func TestLogWithPrefix(t *testing.T) {
 	var buf bytes.Buffer
 	cases := []struct {
@@ -151,3 +201,140 @@ func TestLogWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestSetWriter(t *testing.T) {
+	var a, b bytes.Buffer
+	l := New(&a)
+	assert.Equal(t, &a, l.GetWriter())
+	l.SetWriter(&b)
+	assert.Equal(t, &b, l.GetWriter())
+	l.Info("info")
+	assert.Equal(t, "", a.String())
+	assert.Equal(t, "INFO info\n", b.String())
+}
+
+func TestFormatted(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(ErrorLevel)
+	assert.Equal(t, "DEBU debug foo=bar\n", string(l.Formatted(DebugLevel, "debug", "foo", "bar")))
+	assert.Equal(t, "", buf.String())
+	l.SetFormatter(JSONFormatter)
+	assert.Equal(t, "{\"lvl\":\"info\",\"msg\":\"info\"}\n", string(l.Formatted(InfoLevel, "info")))
+	assert.Equal(t, "", buf.String())
+}
+
+func TestFormattedCaller(t *testing.T) {
+	l := New(ioutil.Discard)
+	l.SetReportCaller(true)
+	_, file, line, _ := runtime.Caller(0)
+	out := l.Formatted(InfoLevel, "info")
+	assert.Equal(t, fmt.Sprintf("INFO <log/%s:%d> info\n", filepath.Base(file), line+1), string(out))
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(DebugLevel)
+	cases := []struct {
+		name     string
+		expected string
+		level    Level
+	}{
+		{
+			name:     "debug",
+			expected: "DEBU msg foo=bar\n",
+			level:    DebugLevel,
+		},
+		{
+			name:     "warn",
+			expected: "WARN msg foo=bar\n",
+			level:    WarnLevel,
+		},
+		{
+			name:     "no level",
+			expected: "msg foo=bar\n",
+			level:    noLevel,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			l.Log(c.level, "msg", "foo", "bar")
+			assert.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestStringer(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithPrefix("app").With("foo", "bar")
+	s := l.Stringer()
+	assert.Equal(t, "DEBU app:  foo=bar", s.String())
+	assert.Equal(t, "line: DEBU app:  foo=bar", fmt.Sprintf("line: %v", s))
+	l.SetFormatter(JSONFormatter)
+	assert.Equal(t, `{"foo":"bar","lvl":"debug","prefix":"app:"}`, s.String())
+	assert.Equal(t, "", buf.String())
+}
+
+func TestLogIf(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(DebugLevel)
+	cases := []struct {
+		name     string
+		expected string
+		f        func(cond bool) bool
+	}{
+		{
+			name:     "log if",
+			expected: "WARN msg foo=bar\n",
+			f:        func(cond bool) bool { return l.LogIf(cond, WarnLevel, "msg", "foo", "bar") },
+		},
+		{
+			name:     "debug if",
+			expected: "DEBU msg foo=bar\n",
+			f:        func(cond bool) bool { return l.DebugIf(cond, "msg", "foo", "bar") },
+		},
+		{
+			name:     "info if",
+			expected: "INFO msg foo=bar\n",
+			f:        func(cond bool) bool { return l.InfoIf(cond, "msg", "foo", "bar") },
+		},
+		{
+			name:     "warn if",
+			expected: "WARN msg foo=bar\n",
+			f:        func(cond bool) bool { return l.WarnIf(cond, "msg", "foo", "bar") },
+		},
+		{
+			name:     "error if",
+			expected: "ERRO msg foo=bar\n",
+			f:        func(cond bool) bool { return l.ErrorIf(cond, "msg", "foo", "bar") },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			assert.False(t, c.f(false))
+			assert.Equal(t, "", buf.String())
+			assert.True(t, c.f(true))
+			assert.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWarnIf_result(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	assert.False(t, l.WarnIf(false, "skipped"))
+	assert.True(t, l.WarnIf(true, "cleanup failed", "err", "boom"))
+	assert.Equal(t, "WARN cleanup failed err=boom\n", buf.String())
+}
+
+func TestErrorIf_result(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	assert.False(t, l.ErrorIf(false, "skipped"))
+	assert.True(t, l.ErrorIf(true, "request failed", "err", "boom"))
+	assert.Equal(t, "ERRO request failed err=boom\n", buf.String())
+}
@@ -0,0 +1,64 @@
+// Package loggrpc provides gRPC server interceptors that log RPCs with a
+// charmbracelet/log logger.
+package loggrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a gRPC unary server interceptor that logs
+// RPCs.
+//
+// Each RPC gets its own logger, derived from l, with the full method name
+// attached. The logger is stored in the RPC context and can be retrieved by
+// handlers with log.FromContext. Once the RPC has been handled, an entry is
+// logged with the duration, at debug level if it succeeded, or at error
+// level with the error if it failed.
+func UnaryServerInterceptor(l *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		rl := l.With("method", info.FullMethod)
+		resp, err := handler(log.WithContext(ctx, rl), req)
+		logRPC(rl, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream server interceptor that logs
+// RPCs, like UnaryServerInterceptor.
+func StreamServerInterceptor(l *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		rl := l.With("method", info.FullMethod)
+		err := handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          log.WithContext(ss.Context(), rl),
+		})
+		logRPC(rl, time.Since(start), err)
+		return err
+	}
+}
+
+// logRPC logs the outcome of an RPC.
+func logRPC(l *log.Logger, d time.Duration, err error) {
+	if err != nil {
+		l.Error("rpc", "duration", d, "error", err)
+		return
+	}
+	l.Debug("rpc", "duration", d)
+}
+
+// serverStream is a grpc.ServerStream with a custom context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,71 @@
+package loggrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var durRe = regexp.MustCompile(`duration=\S+`)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "success",
+			expected: "INFO handling method=/foo.Bar/Baz\nDEBU rpc method=/foo.Bar/Baz duration=<d>\n",
+		},
+		{
+			name:     "failure",
+			err:      errors.New("boom"),
+			expected: "INFO handling method=/foo.Bar/Baz\nERRO rpc method=/foo.Bar/Baz duration=<d> error=boom\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := log.New(&buf)
+			l.SetLevel(log.DebugLevel)
+			i := UnaryServerInterceptor(l)
+			info := &grpc.UnaryServerInfo{FullMethod: "/foo.Bar/Baz"}
+			resp, err := i(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				log.FromContext(ctx).Info("handling")
+				return "resp", c.err
+			})
+			require.Equal(t, c.err, err)
+			require.Equal(t, "resp", resp)
+			require.Equal(t, c.expected, durRe.ReplaceAllString(buf.String(), "duration=<d>"))
+		})
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+}
+
+func (fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.New(&buf)
+	i := StreamServerInterceptor(l)
+	info := &grpc.StreamServerInfo{FullMethod: "/foo.Bar/Stream"}
+	err := i(nil, fakeStream{}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		log.FromContext(ss.Context()).Info("handling")
+		return errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+	expected := "INFO handling method=/foo.Bar/Stream\nERRO rpc method=/foo.Bar/Stream duration=<d> error=boom\n"
+	require.Equal(t, expected, durRe.ReplaceAllString(buf.String(), "duration=<d>"))
+}
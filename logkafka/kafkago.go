@@ -0,0 +1,41 @@
+//go:build !sarama
+// +build !sarama
+
+package logkafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaGoProducer produces messages with kafka-go.
+type kafkaGoProducer struct {
+	w *kafka.Writer
+}
+
+func newProducer(brokers []string, topic string, c config) (producer, error) {
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: c.batchTimeout,
+		Async:        c.async,
+	}
+	if c.async && c.onError != nil {
+		w.Completion = func(_ []kafka.Message, err error) {
+			if err != nil {
+				c.onError(err)
+			}
+		}
+	}
+	return &kafkaGoProducer{w: w}, nil
+}
+
+func (p *kafkaGoProducer) produce(key, value []byte) error {
+	return p.w.WriteMessages(context.Background(), kafka.Message{Key: key, Value: value})
+}
+
+func (p *kafkaGoProducer) close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,77 @@
+//go:build sarama
+// +build sarama
+
+package logkafka
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// saramaProducer produces messages with sarama.
+type saramaProducer struct {
+	topic string
+	sync  sarama.SyncProducer
+	async sarama.AsyncProducer
+	wg    sync.WaitGroup
+}
+
+func newProducer(brokers []string, topic string, c config) (producer, error) {
+	sc := sarama.NewConfig()
+	sc.Producer.Flush.Frequency = c.batchTimeout
+	sc.Producer.Partitioner = sarama.NewHashPartitioner
+
+	p := &saramaProducer{topic: topic}
+	if !c.async {
+		sc.Producer.Return.Successes = true
+		sp, err := sarama.NewSyncProducer(brokers, sc)
+		if err != nil {
+			return nil, err
+		}
+		p.sync = sp
+		return p, nil
+	}
+
+	sc.Producer.Return.Errors = true
+	ap, err := sarama.NewAsyncProducer(brokers, sc)
+	if err != nil {
+		return nil, err
+	}
+	p.async = ap
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for err := range ap.Errors() {
+			if c.onError != nil {
+				c.onError(err)
+			}
+		}
+	}()
+	return p, nil
+}
+
+func (p *saramaProducer) produce(key, value []byte) error {
+	msg := &sarama.ProducerMessage{
+		Topic: p.topic,
+		Value: sarama.ByteEncoder(value),
+	}
+	if key != nil {
+		msg.Key = sarama.ByteEncoder(key)
+	}
+	if p.async != nil {
+		p.async.Input() <- msg
+		return nil
+	}
+	_, _, err := p.sync.SendMessage(msg)
+	return err
+}
+
+func (p *saramaProducer) close() error {
+	if p.async != nil {
+		err := p.async.Close()
+		p.wg.Wait()
+		return err
+	}
+	return p.sync.Close()
+}
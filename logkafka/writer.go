@@ -0,0 +1,128 @@
+// Package logkafka provides a writer that publishes log entries to a Kafka
+// topic.
+//
+// By default, messages are produced with github.com/segmentio/kafka-go.
+// Build with the "sarama" tag to use github.com/IBM/sarama instead.
+package logkafka
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// config is the configuration of a Kafka writer.
+type config struct {
+	async        bool
+	batchTimeout time.Duration
+	onError      func(error)
+}
+
+// KafkaOption is an option for a Kafka writer.
+type KafkaOption func(*config)
+
+// WithAsync returns an option that makes writes return without waiting for
+// the messages to be acknowledged. Delivery errors are reported to the
+// function set with WithErrorHandler.
+func WithAsync() KafkaOption {
+	return func(c *config) {
+		c.async = true
+	}
+}
+
+// WithBatchTimeout returns an option that sets how long messages are
+// buffered before being sent to the brokers.
+func WithBatchTimeout(d time.Duration) KafkaOption {
+	return func(c *config) {
+		c.batchTimeout = d
+	}
+}
+
+// WithErrorHandler returns an option that sets the function called with the
+// errors of asynchronous writes.
+func WithErrorHandler(fn func(error)) KafkaOption {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// producer sends messages to a Kafka topic.
+type producer interface {
+	produce(key, value []byte) error
+	close() error
+}
+
+// writer publishes log entries with a producer.
+type writer struct {
+	mu     sync.Mutex
+	p      producer
+	closed bool
+}
+
+// NewKafkaWriter returns a writer that publishes each formatted log entry as
+// a message to topic. The message key is the prefix of the entry, so that
+// entries of the same logger end up in the same partition. Entries can be
+// written with the text or the JSON formatter.
+//
+// By default, writes wait for the messages to be acknowledged by the
+// brokers. Use WithAsync to return immediately instead.
+func NewKafkaWriter(brokers []string, topic string, opts ...KafkaOption) (io.WriteCloser, error) {
+	c := config{batchTimeout: 10 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	p, err := newProducer(brokers, topic, c)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{p: p}, nil
+}
+
+// Write implements io.Writer.
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	// p is reused by the logger, so it must be copied.
+	value := append([]byte(nil), p...)
+	if err := w.p.produce(entryPrefix(value), value); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes the pending messages and closes the producer.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.p.close()
+}
+
+// entryPrefix returns the prefix of the formatted entry p, or nil if it has
+// none.
+func entryPrefix(p []byte) []byte {
+	var entries []log.LogEntry
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		entries, _ = log.ParseJSON(bytes.NewReader(p))
+	} else {
+		entries, _ = log.Parse(bytes.NewReader(p))
+	}
+	if len(entries) == 0 || entries[0].Prefix == "" {
+		return nil
+	}
+	return []byte(entries[0].Prefix)
+}
@@ -0,0 +1,65 @@
+package logkafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	key, value string
+}
+
+type fakeProducer struct {
+	messages []message
+	err      error
+	closed   bool
+}
+
+func (p *fakeProducer) produce(key, value []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, message{string(key), string(value)})
+	return nil
+}
+
+func (p *fakeProducer) close() error {
+	p.closed = true
+	return nil
+}
+
+func TestWriter(t *testing.T) {
+	p := &fakeProducer{}
+	w := &writer{p: p}
+	l := log.New(w)
+	l.Info("no prefix")
+	l.WithPrefix("api").Info("hello", "foo", "bar")
+	log.NewWithOptions(w, log.Options{Formatter: log.JSONFormatter, Prefix: "db"}).Warn("slow")
+	require.Equal(t, []message{
+		{"", "INFO no prefix\n"},
+		{"api", "INFO api: hello foo=bar\n"},
+		{"db", "{\"lvl\":\"warn\",\"msg\":\"slow\",\"prefix\":\"db:\"}\n"},
+	}, p.messages)
+
+	p.err = errors.New("boom")
+	_, err := w.Write([]byte("INFO failed\n"))
+	require.EqualError(t, err, "boom")
+
+	require.NoError(t, w.Close())
+	require.True(t, p.closed)
+	_, err = w.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrClosed)
+	require.NoError(t, w.Close())
+}
+
+func TestNewKafkaWriter(t *testing.T) {
+	w, err := NewKafkaWriter([]string{"localhost:9092"}, "logs", WithAsync())
+	if err != nil {
+		// The sarama client connects to the brokers when it's created.
+		t.Skip(err)
+	}
+	require.NoError(t, w.Close())
+}
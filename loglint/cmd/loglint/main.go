@@ -0,0 +1,14 @@
+// Command loglint reports common misuses of charmbracelet/log loggers, see
+// the loglint package. It can be run on its own, or by go vet:
+//
+//	go vet -vettool=$(which loglint) ./...
+package main
+
+import (
+	"github.com/charmbracelet/log/loglint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(loglint.Analyzer)
+}
@@ -0,0 +1,121 @@
+// Package loglint defines an analyzer that reports common misuses of
+// charmbracelet/log loggers.
+//
+// It checks the calls to the functions and methods of the log package that
+// take key-value pairs, and reports:
+//
+//   - an odd number of key-value arguments, where a key is missing its value;
+//   - keys that are not string constants;
+//   - duplicate keys in the same call;
+//   - messages built with fmt.Sprintf, when the formatted variant of the
+//     function, like Infof for Info, would do.
+//
+// Calls passing the key-value pairs as a spread slice, like Info(msg, kvs...),
+// are only checked for the fmt.Sprintf message.
+package loglint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// logPath is the import path of the log package.
+const logPath = "github.com/charmbracelet/log"
+
+// Analyzer reports common misuses of charmbracelet/log loggers.
+var Analyzer = &analysis.Analyzer{
+	Name:     "loglint",
+	Doc:      "report misuses of charmbracelet/log key-value pairs and messages",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != logPath {
+			return
+		}
+		sig := fn.Type().(*types.Signature)
+		params := sig.Params()
+		if !sig.Variadic() || params.At(params.Len()-1).Name() != "keyvals" {
+			return
+		}
+		checkMessage(pass, fn, call)
+		if call.Ellipsis.IsValid() {
+			return
+		}
+		checkKeyvals(pass, call.Args[params.Len()-1:])
+	})
+	return nil, nil
+}
+
+// checkKeyvals reports missing values, non-constant keys, and duplicate keys
+// in the key-value arguments of a call.
+func checkKeyvals(pass *analysis.Pass, keyvals []ast.Expr) {
+	seen := make(map[string]bool, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := keyvals[i]
+		if i+1 >= len(keyvals) {
+			pass.Reportf(key.Pos(), "odd number of key-value arguments: missing value for key %s", types.ExprString(key))
+		}
+		tv := pass.TypesInfo.Types[key]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			pass.Reportf(key.Pos(), "key %s is not a string constant", types.ExprString(key))
+			continue
+		}
+		k := constant.StringVal(tv.Value)
+		if seen[k] {
+			pass.Reportf(key.Pos(), "duplicate key %q", k)
+		}
+		seen[k] = true
+	}
+}
+
+// checkMessage reports calls to fn whose message is built with fmt.Sprintf,
+// when fn has a formatted variant and no key-value pairs are passed.
+func checkMessage(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) {
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params()
+	msg := -1
+	for i := 0; i < params.Len(); i++ {
+		if params.At(i).Name() == "msg" {
+			msg = i
+		}
+	}
+	if msg < 0 || len(call.Args) != params.Len()-1 || call.Ellipsis.IsValid() {
+		return
+	}
+	inner, ok := call.Args[msg].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	sprintf, ok := typeutil.Callee(pass.TypesInfo, inner).(*types.Func)
+	if !ok || sprintf.Pkg() == nil || sprintf.Pkg().Path() != "fmt" || sprintf.Name() != "Sprintf" {
+		return
+	}
+	if name := fn.Name() + "f"; hasVariant(fn, name) {
+		pass.Reportf(inner.Pos(), "use %s instead of %s(fmt.Sprintf(...))", name, fn.Name())
+	}
+}
+
+// hasVariant returns true if the package or the receiver of fn has a
+// function or method called name.
+func hasVariant(fn *types.Func, name string) bool {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		_, ok := fn.Pkg().Scope().Lookup(name).(*types.Func)
+		return ok
+	}
+	obj, _, _ := types.LookupFieldOrMethod(recv.Type(), true, fn.Pkg(), name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
@@ -0,0 +1,28 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+)
+
+const userKey = "user"
+
+func calls(l *log.Logger, name string, kvs []interface{}) {
+	l.Info("ok", "user", name, userKey+"_id", 1)
+	l.Info("missing", "user")                   // want `odd number of key-value arguments: missing value for key "user"`
+	l.With("a", 1, "b")                         // want `odd number of key-value arguments: missing value for key "b"`
+	l.Info("key", name, 1)                      // want `key name is not a string constant`
+	l.Info("key", log.ErrorKey, 1)              // want `key log.ErrorKey is not a string constant`
+	l.Info("key", 1, 2)                         // want `key 1 is not a string constant`
+	l.Info("dup", "user", 1, userKey, 2)        // want `duplicate key "user"`
+	l.Log(log.InfoLevel, "dup", "a", 1, "a", 2) // want `duplicate key "a"`
+	l.Info("spread", kvs...)
+
+	l.Info(fmt.Sprintf("user %s", name))    // want `use Infof instead of Info\(fmt.Sprintf\(...\)\)`
+	log.Debug(fmt.Sprintf("user %s", name)) // want `use Debugf instead of Debug\(fmt.Sprintf\(...\)\)`
+	l.Info(fmt.Sprintf("user %s", name), "id", 1)
+	l.Recover(fmt.Sprintf("user %s", name))
+	l.Log(log.InfoLevel, fmt.Sprintf("user %s", name))
+	l.Infoln("a", "b", "c")
+}
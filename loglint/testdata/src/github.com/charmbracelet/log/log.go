@@ -0,0 +1,20 @@
+// Package log is a stub of charmbracelet/log for the loglint tests.
+package log
+
+type Level int
+
+const InfoLevel Level = 0
+
+var ErrorKey = "err"
+
+type Logger struct{}
+
+func (l *Logger) With(keyvals ...interface{}) *Logger                      { return l }
+func (l *Logger) Info(msg interface{}, keyvals ...interface{})             {}
+func (l *Logger) Infof(format string, args ...interface{})                 {}
+func (l *Logger) Log(level Level, msg interface{}, keyvals ...interface{}) {}
+func (l *Logger) Recover(msg string, keyvals ...interface{})               {}
+func (l *Logger) Infoln(args ...interface{})                               {}
+
+func Debug(msg interface{}, keyvals ...interface{}) {}
+func Debugf(format string, args ...interface{})     {}
@@ -0,0 +1,118 @@
+// Package lognats provides a writer that publishes log entries as NATS
+// messages.
+package lognats
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/nats-io/nats.go"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// conn is the part of *nats.Conn used by the writer.
+type conn interface {
+	Publish(subject string, data []byte) error
+	Flush() error
+	IsClosed() bool
+	LastError() error
+}
+
+// Option is an option for a NATS writer.
+type Option func(*writer)
+
+// WithLevelSubject returns an option that publishes entries to a sub-subject
+// of the writer subject named after their level, like "logs.error". Entries
+// without a level are published to the writer subject.
+func WithLevelSubject() Option {
+	return func(w *writer) {
+		w.levelSubject = true
+	}
+}
+
+// writer publishes log entries as NATS messages.
+type writer struct {
+	mu           sync.Mutex
+	nc           conn
+	subject      string
+	levelSubject bool
+	closed       bool
+}
+
+// NewNATSWriter returns a writer that publishes each formatted log entry as
+// a message to subject. Entries can be written with the text or the JSON
+// formatter.
+//
+// Once the connection is closed, writes return the connection error.
+// Closing the writer flushes the connection, but doesn't close it.
+func NewNATSWriter(nc *nats.Conn, subject string, opts ...Option) io.WriteCloser {
+	return newWriter(nc, subject, opts...)
+}
+
+func newWriter(nc conn, subject string, opts ...Option) *writer {
+	w := &writer{nc: nc, subject: subject}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write implements io.Writer.
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	if w.nc.IsClosed() {
+		if err := w.nc.LastError(); err != nil {
+			return 0, err
+		}
+		return 0, nats.ErrConnectionClosed
+	}
+
+	subject := w.subject
+	if w.levelSubject {
+		if level := entryLevel(p); level != "" {
+			subject += "." + level
+		}
+	}
+	if err := w.nc.Publish(subject, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes the connection, and stops accepting new entries.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.nc.IsClosed() {
+		return nil
+	}
+	return w.nc.Flush()
+}
+
+// entryLevel returns the level of the formatted entry p, or an empty string
+// if it has none.
+func entryLevel(p []byte) string {
+	var entries []log.LogEntry
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		entries, _ = log.ParseJSON(bytes.NewReader(p))
+	} else {
+		entries, _ = log.Parse(bytes.NewReader(p))
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[0].Level.String()
+}
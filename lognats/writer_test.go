@@ -0,0 +1,72 @@
+package lognats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	subject, data string
+}
+
+type fakeConn struct {
+	messages []message
+	closed   bool
+	lastErr  error
+	flushed  bool
+}
+
+func (c *fakeConn) Publish(subject string, data []byte) error {
+	c.messages = append(c.messages, message{subject, string(data)})
+	return nil
+}
+
+func (c *fakeConn) Flush() error {
+	c.flushed = true
+	return nil
+}
+
+func (c *fakeConn) IsClosed() bool   { return c.closed }
+func (c *fakeConn) LastError() error { return c.lastErr }
+
+func TestWriter(t *testing.T) {
+	nc := &fakeConn{}
+	w := newWriter(nc, "logs")
+	log.New(w).Info("hello", "foo", "bar")
+	require.Equal(t, []message{{"logs", "INFO hello foo=bar\n"}}, nc.messages)
+	require.NoError(t, w.Close())
+	require.True(t, nc.flushed)
+	_, err := w.Write([]byte("INFO closed\n"))
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestWriter_levelSubject(t *testing.T) {
+	nc := &fakeConn{}
+	w := newWriter(nc, "logs", WithLevelSubject())
+	l := log.New(w)
+	l.Error("failed")
+	l.Print("no level")
+	log.NewWithOptions(w, log.Options{Formatter: log.JSONFormatter}).Warn("json")
+	require.Equal(t, []message{
+		{"logs.error", "ERRO failed\n"},
+		{"logs", "no level\n"},
+		{"logs.warn", "{\"lvl\":\"warn\",\"msg\":\"json\"}\n"},
+	}, nc.messages)
+}
+
+func TestWriter_connClosed(t *testing.T) {
+	nc := &fakeConn{closed: true}
+	w := newWriter(nc, "logs")
+	_, err := w.Write([]byte("INFO hello\n"))
+	require.ErrorIs(t, err, nats.ErrConnectionClosed)
+
+	nc.lastErr = errors.New("boom")
+	_, err = w.Write([]byte("INFO hello\n"))
+	require.EqualError(t, err, "boom")
+	require.NoError(t, w.Close())
+	require.Empty(t, nc.messages)
+}
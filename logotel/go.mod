@@ -0,0 +1,33 @@
+module github.com/charmbracelet/log/logotel
+
+go 1.20
+
+replace github.com/charmbracelet/log => ../
+
+require (
+	github.com/charmbracelet/log v0.0.0
+	github.com/stretchr/testify v1.8.2
+	go.opentelemetry.io/otel v1.16.0-rc.1
+	go.opentelemetry.io/otel/sdk v1.16.0-rc.1
+	go.opentelemetry.io/otel/trace v1.16.0-rc.1
+)
+
+require (
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/lipgloss v0.7.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logfmt/logfmt v0.6.0 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.18 // indirect
+	github.com/mattn/go-runewidth v0.0.14 // indirect
+	github.com/muesli/reflow v0.3.0 // indirect
+	github.com/muesli/termenv v0.15.1 // indirect
+	github.com/petermattis/goid v0.0.0-20260918085751-abfca077860b // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rivo/uniseg v0.2.0 // indirect
+	go.opentelemetry.io/otel/metric v1.16.0-rc.1 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
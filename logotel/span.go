@@ -0,0 +1,82 @@
+// Package logotel integrates charmbracelet/log loggers with OpenTelemetry
+// tracing.
+package logotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LevelKey is the attribute key of the entry level in span events.
+const LevelKey = attribute.Key("log.level")
+
+// WithSpanEvent returns a new logger, derived from l, that records entries as
+// events of span instead of writing them. The message is the event name,
+// and the level and key-value pairs, including the logger fields, are the
+// event attributes. Entries filtered by level are dropped as usual.
+func WithSpanEvent(l *log.Logger, span trace.Span) *log.Logger {
+	return l.Intercept(func(level log.Level, msg string, keyvals []interface{}) (log.Level, string, []interface{}) {
+		attrs := make([]attribute.KeyValue, 0, len(keyvals)/2+1)
+		if s := level.String(); s != "" {
+			attrs = append(attrs, LevelKey.String(s))
+		}
+		attrs = append(attrs, Attributes(keyvals...)...)
+		span.AddEvent(msg, trace.WithAttributes(attrs...))
+		return log.DropLevel, msg, keyvals
+	})
+}
+
+// SpanContext returns the trace_id and span_id fields of the span in ctx, or
+// nil if there's no valid span. It's meant to be used as a context extractor,
+// so that entries logged with a context are correlated with its trace:
+//
+//	logger := log.New(os.Stderr, log.WithContextExtractor(logotel.SpanContext))
+func SpanContext(ctx context.Context) []interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []interface{}{
+		"trace_id", sc.TraceID().String(),
+		"span_id", sc.SpanID().String(),
+	}
+}
+
+// Attributes converts key-value pairs into OpenTelemetry attributes.
+func Attributes(keyvals ...interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		attrs = append(attrs, attr(fmt.Sprint(keyvals[i]), keyvals[i+1]))
+	}
+	return attrs
+}
+
+// attr converts a key-value pair into an attribute, keeping the type of
+// basic values.
+func attr(key string, v interface{}) attribute.KeyValue {
+	k := attribute.Key(key)
+	switch v := v.(type) {
+	case string:
+		return k.String(v)
+	case bool:
+		return k.Bool(v)
+	case int:
+		return k.Int(v)
+	case int64:
+		return k.Int64(v)
+	case float64:
+		return k.Float64(v)
+	case []string:
+		return k.StringSlice(v)
+	case error:
+		return k.String(v.Error())
+	case fmt.Stringer:
+		return k.String(v.String())
+	default:
+		return k.String(fmt.Sprint(v))
+	}
+}
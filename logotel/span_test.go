@@ -0,0 +1,57 @@
+package logotel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithSpanEvent(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	_, span := tp.Tracer("test").Start(context.Background(), "op")
+
+	var buf bytes.Buffer
+	l := log.New(&buf).With("service", "api")
+	sl := WithSpanEvent(l, span)
+	sl.Info("hello", "count", 42, "ok", true, "err", errors.New("boom"))
+	sl.Debug("filtered")
+	l.Info("written")
+	span.End()
+
+	require.Equal(t, "INFO written service=api\n", buf.String())
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 1)
+	require.Equal(t, "hello", events[0].Name)
+	require.Equal(t, []attribute.KeyValue{
+		LevelKey.String("info"),
+		attribute.String("service", "api"),
+		attribute.Int("count", 42),
+		attribute.Bool("ok", true),
+		attribute.String("err", "boom"),
+	}, events[0].Attributes)
+}
+
+func TestSpanContext(t *testing.T) {
+	require.Nil(t, SpanContext(context.Background()))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	sc := span.SpanContext()
+
+	var buf bytes.Buffer
+	l := log.New(&buf, log.WithContextExtractor(SpanContext))
+	_, finish := l.SpanStart(ctx, "sync")
+	finish(nil)
+	require.Contains(t, buf.String(), "operation=sync trace_id="+sc.TraceID().String()+" span_id="+sc.SpanID().String())
+}
@@ -0,0 +1,63 @@
+// Package logproto provides loggable values for protocol buffer messages.
+package logproto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// nilJSON is the JSON representation of a nil message.
+var nilJSON = []byte(`"nil"`)
+
+// LogValue is a loggable value holding the JSON representation of a
+// protocol buffer message. The JSON formatter embeds it as is, while the text
+// and logfmt formatters write it as compact JSON.
+type LogValue struct {
+	json []byte
+}
+
+// Proto returns a loggable value for msg, serialized to JSON with protojson:
+//
+//	logger.Info("request", "req", logproto.Proto(req))
+//
+// A nil message is logged as "nil", and a message that can't be serialized
+// as its serialization error.
+func Proto(msg proto.Message) LogValue {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return LogValue{json: nilJSON}
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprintf("!ERROR: %v", err))
+		return LogValue{json: b}
+	}
+	// protojson randomizes whitespace, compact it for stable output.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return LogValue{json: b}
+	}
+	return LogValue{json: buf.Bytes()}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v LogValue) MarshalJSON() ([]byte, error) {
+	if v.json == nil {
+		return nilJSON, nil
+	}
+	return v.json, nil
+}
+
+// Format implements fmt.Formatter. It writes the JSON representation of the
+// message, or nil for a nil message. It's used instead of fmt.Stringer so
+// that the JSON formatter doesn't write the message as a string.
+func (v LogValue) Format(f fmt.State, _ rune) {
+	if v.json == nil || bytes.Equal(v.json, nilJSON) {
+		_, _ = f.Write([]byte("nil"))
+		return
+	}
+	_, _ = f.Write(v.json)
+}
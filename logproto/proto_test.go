@@ -0,0 +1,70 @@
+package logproto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProto(t *testing.T) {
+	req, err := structpb.NewStruct(map[string]interface{}{"name": "foo", "size": 2})
+	require.NoError(t, err)
+	var nilMsg *wrapperspb.StringValue
+
+	cases := []struct {
+		name      string
+		formatter log.Formatter
+		value     LogValue
+		expected  string
+	}{
+		{
+			name:      "text",
+			formatter: log.TextFormatter,
+			value:     Proto(req),
+			expected:  `INFO request req="{\"name\":\"foo\",\"size\":2}"` + "\n",
+		},
+		{
+			name:      "json",
+			formatter: log.JSONFormatter,
+			value:     Proto(req),
+			expected:  `{"lvl":"info","msg":"request","req":{"name":"foo","size":2}}` + "\n",
+		},
+		{
+			name:      "logfmt",
+			formatter: log.LogfmtFormatter,
+			value:     Proto(wrapperspb.String("bar")),
+			expected:  `lvl=info msg=request req="\"bar\""` + "\n",
+		},
+		{
+			name:      "nil text",
+			formatter: log.TextFormatter,
+			value:     Proto(nil),
+			expected:  "INFO request req=nil\n",
+		},
+		{
+			name:      "typed nil json",
+			formatter: log.JSONFormatter,
+			value:     Proto(nilMsg),
+			expected:  `{"lvl":"info","msg":"request","req":"nil"}` + "\n",
+		},
+		{
+			name:      "zero value json",
+			formatter: log.JSONFormatter,
+			value:     LogValue{},
+			expected:  `{"lvl":"info","msg":"request","req":"nil"}` + "\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := log.New(&buf)
+			l.SetFormatter(c.formatter)
+			l.Info("request", "req", c.value)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
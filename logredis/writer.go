@@ -0,0 +1,132 @@
+// Package logredis provides a writer that publishes log entries to a Redis
+// pub/sub channel.
+package logredis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("writer closed")
+
+// DefaultBufferSize is the default number of entries buffered while Redis
+// is unreachable.
+const DefaultBufferSize = 1000
+
+// Option is an option for a Redis writer.
+type Option func(*writer)
+
+// WithBufferSize returns an option that sets the number of entries buffered
+// while Redis is unreachable. When the buffer is full, the oldest entries
+// are dropped.
+func WithBufferSize(n int) Option {
+	return func(w *writer) {
+		if n > 0 {
+			w.size = n
+		}
+	}
+}
+
+// WithTimeout returns an option that sets the timeout of each publish.
+func WithTimeout(d time.Duration) Option {
+	return func(w *writer) {
+		w.timeout = d
+	}
+}
+
+// writer publishes each formatted log entry to a Redis pub/sub channel.
+type writer struct {
+	mu      sync.Mutex
+	client  *redis.Client
+	channel string
+	size    int
+	timeout time.Duration
+	pending [][]byte
+	closed  bool
+}
+
+// NewRedisWriter returns a writer that publishes each formatted log entry to
+// channel. Entries that can't be published, like when Redis is unreachable,
+// are buffered in memory and published again before the next entry.
+//
+// Closing the writer publishes the buffered entries, but doesn't close the
+// client.
+func NewRedisWriter(client *redis.Client, channel string, opts ...Option) io.WriteCloser {
+	w := &writer{
+		client:  client,
+		channel: channel,
+		size:    DefaultBufferSize,
+		timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write implements io.Writer. If the entry can't be published, it's buffered
+// and the error is returned.
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	// p is reused by the logger, so it must be copied to be buffered.
+	w.pending = append(w.pending, append([]byte(nil), p...))
+	if len(w.pending) > w.size {
+		w.pending = w.pending[len(w.pending)-w.size:]
+	}
+	if err := w.flush(); err != nil {
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+// Flush publishes the buffered entries.
+func (w *writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+// Close publishes the buffered entries, and stops accepting new ones.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush()
+}
+
+// flush publishes the buffered entries in order, and stops at the first
+// failure. It must be called with the lock held.
+func (w *writer) flush() error {
+	for len(w.pending) > 0 {
+		if err := w.publish(w.pending[0]); err != nil {
+			return err
+		}
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+	}
+	return nil
+}
+
+func (w *writer) publish(p []byte) error {
+	ctx := context.Background()
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+	return w.client.Publish(ctx, w.channel, p).Err()
+}
@@ -0,0 +1,67 @@
+package logredis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/charmbracelet/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisWriter(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	sub := client.Subscribe(context.Background(), "logs")
+	defer sub.Close()
+	_, err := sub.Receive(context.Background())
+	require.NoError(t, err)
+
+	w := NewRedisWriter(client, "logs")
+	l := log.New(w)
+	l.Info("hello", "foo", "bar")
+	l.Error("failed")
+	require.NoError(t, w.Close())
+
+	for _, expected := range []string{"INFO hello foo=bar\n", "ERRO failed\n"} {
+		msg, err := sub.ReceiveMessage(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, msg.Payload)
+	}
+
+	_, err = w.Write([]byte("closed"))
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestRedisWriter_buffer(t *testing.T) {
+	s := miniredis.RunT(t)
+	addr := s.Addr()
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1})
+	defer client.Close()
+
+	w := NewRedisWriter(client, "logs", WithBufferSize(2), WithTimeout(100*time.Millisecond)).(*writer)
+	s.Close()
+	for _, msg := range []string{"one", "two", "three"} {
+		_, err := w.Write([]byte(msg))
+		require.Error(t, err)
+	}
+	require.Len(t, w.pending, 2)
+
+	require.NoError(t, s.StartAddr(addr))
+	sub := client.Subscribe(context.Background(), "logs")
+	defer sub.Close()
+	_, err := sub.Receive(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, w.Flush())
+	require.Empty(t, w.pending)
+	for _, expected := range []string{"two", "three"} {
+		msg, err := sub.ReceiveMessage(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, msg.Payload)
+	}
+}
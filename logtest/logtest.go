@@ -0,0 +1,74 @@
+// Package logtest provides loggers for tests.
+package logtest
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+// config is the configuration of a test logger.
+type config struct {
+	expectErrors bool
+}
+
+// Option is an option for test loggers.
+type Option func(*config)
+
+// WithExpectErrors returns an option that allows the test logger to log
+// error and fatal entries, for tests covering failures.
+func WithExpectErrors() Option {
+	return func(c *config) {
+		c.expectErrors = true
+	}
+}
+
+// NewTestIsolatedLogger returns a new logger for the test t, independent from
+// the default logger and from the loggers of other tests. It logs all levels
+// to a buffer, and fails t once the test and its subtests complete if error
+// or fatal entries were logged, with the captured entries in the failure
+// message, unless WithExpectErrors is used. This keeps tests from passing
+// while errors are logged.
+func NewTestIsolatedLogger(t testing.TB, opts ...Option) *log.Logger {
+	t.Helper()
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	errors := 0
+	l := log.New(&lockedWriter{mu: &mu, w: &buf}, log.WithWriteNotifier(func(level log.Level, _ int) {
+		if level == log.ErrorLevel || level == log.FatalLevel {
+			mu.Lock()
+			errors++
+			mu.Unlock()
+		}
+	}))
+	l.SetLevel(log.DebugLevel)
+
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if errors > 0 && !c.expectErrors {
+			t.Errorf("logger logged %d error entries:\n%s", errors, buf.String())
+		}
+	})
+	return l
+}
+
+// lockedWriter writes to w while holding mu.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
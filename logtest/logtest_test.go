@@ -0,0 +1,63 @@
+package logtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTB records the failures and cleanups of a test.
+type fakeTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTB) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestNewTestIsolatedLogger(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	l := NewTestIsolatedLogger(tb)
+	require.NotSame(t, log.Default(), l)
+	l.Debug("starting")
+	l.With("id", 1).Error("failed", "err", "boom")
+	l.Warn("retrying")
+	l.Error("failed again")
+	tb.cleanup()
+	require.Equal(t, []string{
+		"logger logged 2 error entries:\nDEBU starting\nERRO failed id=1 err=boom\nWARN retrying\nERRO failed again\n",
+	}, tb.errors)
+}
+
+func TestNewTestIsolatedLogger_noErrors(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	l := NewTestIsolatedLogger(tb)
+	l.Info("hello")
+	l.Warn("careful")
+	tb.cleanup()
+	require.Empty(t, tb.errors)
+}
+
+func TestWithExpectErrors(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	l := NewTestIsolatedLogger(tb, WithExpectErrors())
+	l.Error("expected")
+	tb.cleanup()
+	require.Empty(t, tb.errors)
+}
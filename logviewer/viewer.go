@@ -0,0 +1,365 @@
+// Package logviewer provides a terminal user interface, built with
+// bubbletea, that displays the recent entries of a logger.
+package logviewer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// DefaultMaxEntries is the default number of entries kept by a viewer.
+const DefaultMaxEntries = 1000
+
+// defaultHeight is the height of the viewer until the size of the terminal
+// is known.
+const defaultHeight = 24
+
+// levels are the levels the viewer can filter entries by, in order.
+var levels = []log.Level{
+	log.DebugLevel,
+	log.InfoLevel,
+	log.WarnLevel,
+	log.ErrorLevel,
+	log.FatalLevel,
+}
+
+var (
+	// HeaderStyle is the style of the header of the viewer.
+	HeaderStyle = lipgloss.NewStyle().Bold(true)
+
+	// HelpStyle is the style of the key bindings help of the viewer.
+	HelpStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// EntriesMsg is a message that adds entries to the viewer.
+type EntriesMsg []log.LogEntry
+
+// LogViewer returns a program that displays the entries of l, whose output is
+// redirected to the program. Entries can be written with the text or the JSON
+// formatter.
+//
+// Entries logged before the program runs are held until it starts, up to
+// DefaultMaxEntries, dropping the oldest ones. Logging never waits for the
+// program. Once it exits, entries are discarded until the output of l is set
+// again.
+func LogViewer(l *log.Logger) *tea.Program {
+	p := tea.NewProgram(NewLogViewerModel(DefaultMaxEntries), tea.WithAltScreen())
+	f := newForwarder(DefaultMaxEntries)
+	l.SetOutput(f)
+	go f.run(p.Send)
+	return p
+}
+
+// forwarder is a writer that parses the entries written to it, and holds them
+// until they're sent to the program. It keeps the last max entries while the
+// program isn't receiving them.
+type forwarder struct {
+	mu      sync.Mutex
+	pending []log.LogEntry
+	max     int
+	// ready is signaled when entries are pending.
+	ready chan struct{}
+}
+
+func newForwarder(max int) *forwarder {
+	return &forwarder{max: max, ready: make(chan struct{}, 1)}
+}
+
+// Write implements io.Writer. Each write is expected to hold whole entries,
+// as written by a logger.
+func (f *forwarder) Write(p []byte) (int, error) {
+	entries := parse(p)
+	f.mu.Lock()
+	f.pending = append(f.pending, entries...)
+	if n := len(f.pending) - f.max; n > 0 {
+		f.pending = append([]log.LogEntry(nil), f.pending[n:]...)
+	}
+	f.mu.Unlock()
+	select {
+	case f.ready <- struct{}{}:
+	default:
+	}
+	return len(p), nil
+}
+
+// run sends the pending entries with send, as they're written.
+func (f *forwarder) run(send func(tea.Msg)) {
+	for range f.ready {
+		f.mu.Lock()
+		entries := f.pending
+		f.pending = nil
+		f.mu.Unlock()
+		if len(entries) > 0 {
+			send(EntriesMsg(entries))
+		}
+	}
+}
+
+// parse parses entries written with the text or JSON formatter. Lines that
+// can't be parsed are kept as info messages.
+func parse(p []byte) []log.LogEntry {
+	var entries []log.LogEntry
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(p), []byte("{")) {
+		entries, err = log.ParseJSON(bytes.NewReader(p))
+	} else {
+		entries, err = log.Parse(bytes.NewReader(p))
+	}
+	if err == nil {
+		return entries
+	}
+	entries = entries[:0]
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		entries = append(entries, log.LogEntry{Level: log.InfoLevel, Message: line})
+	}
+	return entries
+}
+
+// LogViewerModel is a bubbletea model that displays log entries, with
+// scrolling, filtering by level, and search by message.
+//
+// Key bindings:
+//
+//	↑/k, ↓/j      scroll by one line
+//	pgup, pgdown  scroll by one page
+//	g, G          scroll to the top, or to the bottom
+//	l, L          raise or lower the minimum level
+//	/             search messages, enter to confirm, esc to clear
+//	q, ctrl+c     quit
+type LogViewerModel struct {
+	entries    []log.LogEntry
+	maxEntries int
+	level      log.Level
+	search     string
+	searching  bool
+	// offset is the number of lines scrolled up from the bottom.
+	offset int
+	width  int
+	height int
+}
+
+// NewLogViewerModel returns a model that keeps the last maxEntries entries,
+// or DefaultMaxEntries if maxEntries isn't positive.
+func NewLogViewerModel(maxEntries int) LogViewerModel {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return LogViewerModel{
+		maxEntries: maxEntries,
+		level:      log.DebugLevel,
+		height:     defaultHeight,
+	}
+}
+
+// Init implements tea.Model.
+func (m LogViewerModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m LogViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case EntriesMsg:
+		m.entries = append(m.entries, msg...)
+		if n := len(m.entries) - m.maxEntries; n > 0 {
+			m.entries = append([]log.LogEntry(nil), m.entries[n:]...)
+		}
+		if m.offset > 0 {
+			// Keep the same lines on screen while scrolled up.
+			m.offset += len(m.filter(msg))
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			m.offset++
+		case "down", "j":
+			m.offset--
+		case "pgup":
+			m.offset += m.pageHeight()
+		case "pgdown":
+			m.offset -= m.pageHeight()
+		case "home", "g":
+			m.offset = len(m.visible())
+		case "end", "G":
+			m.offset = 0
+		case "l":
+			m.level = nextLevel(m.level, 1)
+		case "L":
+			m.level = nextLevel(m.level, -1)
+		case "/":
+			m.searching = true
+		}
+	}
+	m.clampOffset()
+	return m, nil
+}
+
+// updateSearch handles keys while typing a search.
+func (m LogViewerModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.search = ""
+	case tea.KeyBackspace:
+		if r := []rune(m.search); len(r) > 0 {
+			m.search = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(msg.Runes)
+	}
+	m.offset = 0
+	return m, nil
+}
+
+// nextLevel returns the level after level in the given direction, wrapping
+// around.
+func nextLevel(level log.Level, dir int) log.Level {
+	for i, l := range levels {
+		if l == level {
+			return levels[(i+dir+len(levels))%len(levels)]
+		}
+	}
+	return levels[0]
+}
+
+// pageHeight returns the number of entries displayed at once.
+func (m LogViewerModel) pageHeight() int {
+	// Leave room for the header and the help.
+	if h := m.height - 2; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// clampOffset keeps the offset within the filtered entries.
+func (m *LogViewerModel) clampOffset() {
+	if limit := len(m.visible()) - m.pageHeight(); m.offset > limit {
+		m.offset = limit
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// filter returns the entries at or above the level whose message contains
+// the search.
+func (m LogViewerModel) filter(entries []log.LogEntry) []log.LogEntry {
+	var matched []log.LogEntry
+	search := strings.ToLower(m.search)
+	for _, e := range entries {
+		if e.Level < m.level {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Message), search) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	return matched
+}
+
+// visible returns the entries matching the filters.
+func (m LogViewerModel) visible() []log.LogEntry {
+	return m.filter(m.entries)
+}
+
+// View implements tea.Model.
+func (m LogViewerModel) View() string {
+	var b strings.Builder
+	header := fmt.Sprintf("level ≥ %s", strings.ToUpper(m.level.String()))
+	if m.search != "" || m.searching {
+		header += fmt.Sprintf("  search: %s", m.search)
+		if m.searching {
+			header += "█"
+		}
+	}
+	b.WriteString(HeaderStyle.Render(header))
+	b.WriteByte('\n')
+
+	entries := m.visible()
+	end := len(entries) - m.offset
+	start := end - m.pageHeight()
+	if start < 0 {
+		start = 0
+	}
+	for _, e := range entries[start:end] {
+		line := formatEntry(e)
+		if m.width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	for i := end - start; i < m.pageHeight(); i++ {
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(HelpStyle.Render("↑/↓ scroll • l/L level • / search • q quit"))
+	return b.String()
+}
+
+// formatEntry formats an entry on a single line.
+func formatEntry(e log.LogEntry) string {
+	var parts []string
+	if !e.Time.IsZero() {
+		parts = append(parts, log.TimestampStyle.Render(e.Time.Format("15:04:05")))
+	}
+	if lvl := levelStyle(e.Level).String(); lvl != "" {
+		parts = append(parts, lvl)
+	}
+	if e.Caller != "" {
+		parts = append(parts, log.CallerStyle.Render("<"+e.Caller+">"))
+	}
+	if e.Prefix != "" {
+		parts = append(parts, log.PrefixStyle.Render(e.Prefix+":"))
+	}
+	if e.Message != "" {
+		parts = append(parts, log.MessageStyle.Render(oneLine(e.Message)))
+	}
+	for i := 0; i+1 < len(e.Fields); i += 2 {
+		parts = append(parts, log.KeyStyle.Render(fmt.Sprint(e.Fields[i]))+
+			log.SeparatorStyle.Render("=")+
+			log.ValueStyle.Render(oneLine(fmt.Sprint(e.Fields[i+1]))))
+	}
+	return strings.Join(parts, " ")
+}
+
+// levelStyle returns the style of a level.
+func levelStyle(level log.Level) lipgloss.Style {
+	switch level {
+	case log.DebugLevel:
+		return log.DebugLevelStyle
+	case log.InfoLevel:
+		return log.InfoLevelStyle
+	case log.WarnLevel:
+		return log.WarnLevelStyle
+	case log.ErrorLevel:
+		return log.ErrorLevelStyle
+	case log.FatalLevel:
+		return log.FatalLevelStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// oneLine escapes the newlines of s.
+func oneLine(s string) string {
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
@@ -0,0 +1,189 @@
+package logviewer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+)
+
+func update(t *testing.T, m LogViewerModel, msgs ...tea.Msg) LogViewerModel {
+	t.Helper()
+	for _, msg := range msgs {
+		tm, _ := m.Update(msg)
+		m = tm.(LogViewerModel)
+	}
+	return m
+}
+
+func keys(s string) []tea.Msg {
+	var msgs []tea.Msg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+func entries(n int) EntriesMsg {
+	var msg EntriesMsg
+	for i := 0; i < n; i++ {
+		msg = append(msg, log.LogEntry{Level: log.InfoLevel, Message: "entry " + string(rune('a'+i))})
+	}
+	return msg
+}
+
+// lines returns the entry lines of the view, without header and help.
+func lines(m LogViewerModel) []string {
+	ls := strings.Split(m.View(), "\n")
+	var out []string
+	for _, l := range ls[1 : len(ls)-1] {
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func TestLogViewerModel(t *testing.T) {
+	m := update(t, NewLogViewerModel(0),
+		tea.WindowSizeMsg{Width: 80, Height: 5},
+		EntriesMsg{
+			{Level: log.DebugLevel, Message: "starting"},
+			{Time: time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC), Level: log.InfoLevel, Prefix: "api", Message: "listening", Fields: []interface{}{"port", "8080"}},
+			{Level: log.ErrorLevel, Message: "request failed", Fields: []interface{}{"err", "multi\nline"}},
+		},
+	)
+	require.Equal(t, []string{
+		"DEBU starting",
+		"06:07:08 INFO api: listening port=8080",
+		`ERRO request failed err=multi\nline`,
+	}, lines(m))
+	require.True(t, strings.HasPrefix(m.View(), "level ≥ DEBUG\n"))
+
+	m = update(t, m, keys("ll")...)
+	require.Equal(t, []string{`ERRO request failed err=multi\nline`}, lines(m))
+	m = update(t, m, keys("L")...)
+	require.Len(t, lines(m), 2)
+	m = update(t, m, keys("LL")...)
+	require.Equal(t, log.FatalLevel, m.level)
+}
+
+func TestLogViewerModel_scroll(t *testing.T) {
+	m := update(t, NewLogViewerModel(0), tea.WindowSizeMsg{Width: 80, Height: 4}, entries(5))
+	require.Equal(t, []string{"INFO entry d", "INFO entry e"}, lines(m))
+
+	m = update(t, m, keys("k")...)
+	require.Equal(t, []string{"INFO entry c", "INFO entry d"}, lines(m))
+
+	// New entries don't move the lines on screen while scrolled up.
+	m = update(t, m, EntriesMsg{{Level: log.InfoLevel, Message: "entry f"}})
+	require.Equal(t, []string{"INFO entry c", "INFO entry d"}, lines(m))
+
+	m = update(t, m, keys("g")...)
+	require.Equal(t, []string{"INFO entry a", "INFO entry b"}, lines(m))
+	m = update(t, m, keys("kkk")...)
+	require.Equal(t, []string{"INFO entry a", "INFO entry b"}, lines(m))
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyPgDown})
+	require.Equal(t, []string{"INFO entry c", "INFO entry d"}, lines(m))
+	m = update(t, m, keys("G")...)
+	require.Equal(t, []string{"INFO entry e", "INFO entry f"}, lines(m))
+	m = update(t, m, keys("jj")...)
+	require.Equal(t, []string{"INFO entry e", "INFO entry f"}, lines(m))
+}
+
+func TestLogViewerModel_search(t *testing.T) {
+	m := update(t, NewLogViewerModel(0), EntriesMsg{
+		{Level: log.InfoLevel, Message: "Request started"},
+		{Level: log.InfoLevel, Message: "request done"},
+		{Level: log.InfoLevel, Message: "shutting down"},
+	})
+	m = update(t, m, keys("/req")...)
+	require.True(t, m.searching)
+	require.True(t, strings.HasPrefix(m.View(), "level ≥ DEBUG  search: req█\n"))
+	require.Equal(t, []string{"INFO Request started", "INFO request done"}, lines(m))
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, m.searching)
+	require.Equal(t, "re", m.search)
+
+	// q quits outside of search only.
+	_, cmd := m.Update(keys("q")[0])
+	require.NotNil(t, cmd)
+	m = update(t, m, keys("/q")...)
+	require.Equal(t, "req", m.search)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	require.Empty(t, m.search)
+	require.Len(t, lines(m), 3)
+}
+
+func TestLogViewerModel_maxEntries(t *testing.T) {
+	m := update(t, NewLogViewerModel(2), entries(3))
+	require.Equal(t, []string{"INFO entry b", "INFO entry c"}, lines(m))
+}
+
+func TestForwarder(t *testing.T) {
+	f := newForwarder(2)
+	l := log.New(f)
+	l.Info("dropped")
+	l.Info("hello", "foo", "bar")
+	l.SetFormatter(log.JSONFormatter)
+	l.Warn("json")
+
+	msgs := make(chan tea.Msg)
+	go f.run(func(msg tea.Msg) { msgs <- msg })
+	require.Equal(t, EntriesMsg{
+		{Level: log.InfoLevel, Message: "hello", Fields: []interface{}{"foo", "bar"}},
+		{Level: log.WarnLevel, Message: "json"},
+	}, stripUnexported(<-msgs))
+
+	_, _ = f.Write([]byte("{\"msg\": broken\n"))
+	require.Equal(t, EntriesMsg{{Level: log.InfoLevel, Message: `{"msg": broken`}}, <-msgs)
+}
+
+func TestForwarder_nonBlocking(t *testing.T) {
+	f := newForwarder(DefaultMaxEntries)
+	l := log.New(f)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*DefaultMaxEntries; i++ {
+			l.Info("entry", "i", i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked without a running program")
+	}
+	require.Len(t, f.pending, DefaultMaxEntries)
+	require.Equal(t, []interface{}{"i", fmt.Sprint(DefaultMaxEntries)}, f.pending[0].Fields)
+}
+
+// stripUnexported drops the unexported fields of parsed entries, for
+// comparisons.
+func stripUnexported(msg tea.Msg) EntriesMsg {
+	var out EntriesMsg
+	for _, e := range msg.(EntriesMsg) {
+		out = append(out, log.LogEntry{
+			Time:    e.Time,
+			Level:   e.Level,
+			Prefix:  e.Prefix,
+			Caller:  e.Caller,
+			Message: e.Message,
+			Fields:  e.Fields,
+		})
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	var buf bytes.Buffer
+	log.New(&buf).Error("failed")
+	require.Len(t, parse(buf.Bytes()), 1)
+}
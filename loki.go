@@ -0,0 +1,145 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+// LokiLevelLabel is the label holding the level of entries pushed to Loki.
+const LokiLevelLabel = "level"
+
+// lokiConfig is the configuration of a Loki writer.
+type lokiConfig struct {
+	client *http.Client
+	batch  []BatchOption
+}
+
+// LokiOption is an option for a Loki writer.
+type LokiOption func(*lokiConfig)
+
+// WithLokiClient returns a Loki option that pushes entries with client,
+// instead of http.DefaultClient.
+func WithLokiClient(client *http.Client) LokiOption {
+	return func(c *lokiConfig) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithLokiBatchSize returns a Loki option that pushes entries once n of them
+// are pending.
+func WithLokiBatchSize(n int) LokiOption {
+	return func(c *lokiConfig) {
+		c.batch = append(c.batch, WithBatchSize(n))
+	}
+}
+
+// WithLokiFlushInterval returns a Loki option that pushes pending entries
+// every interval, regardless of their number.
+func WithLokiFlushInterval(interval time.Duration) LokiOption {
+	return func(c *lokiConfig) {
+		c.batch = append(c.batch, WithBatchInterval(interval))
+	}
+}
+
+// NewLokiWriter returns a writer that pushes log entries to Grafana Loki,
+// using the HTTP push API at pushURL, usually ending with
+// "/loki/api/v1/push".
+//
+// Entries are pushed in streams with the given labels, along with the level
+// of the entries as the "level" label. Each line holds the prefix, caller,
+// message, and fields of an entry, as logfmt. Entries can be written with
+// the text or the JSON formatter, and are pushed in batches.
+//
+// Close the writer to push the pending entries and stop batching.
+func NewLokiWriter(pushURL string, labels map[string]string, opts ...LokiOption) io.WriteCloser {
+	c := lokiConfig{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return newBatcher(func(entries []LogEntry) error {
+		return pushLoki(c.client, pushURL, labels, entries)
+	}, c.batch...)
+}
+
+// lokiStream is a stream of the Loki push API.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// pushLoki pushes entries to Loki, in one stream per level.
+func pushLoki(client *http.Client, pushURL string, labels map[string]string, entries []LogEntry) error {
+	var streams []*lokiStream
+	byLevel := make(map[Level]*lokiStream)
+	now := time.Now()
+	for _, e := range entries {
+		s, ok := byLevel[e.Level]
+		if !ok {
+			s = &lokiStream{Stream: make(map[string]string, len(labels)+1)}
+			for k, v := range labels {
+				s.Stream[k] = v
+			}
+			if e.Level != noLevel {
+				s.Stream[LokiLevelLabel] = e.Level.String()
+			}
+			byLevel[e.Level] = s
+			streams = append(streams, s)
+		}
+		ts := e.Time
+		if ts.IsZero() {
+			ts = now
+		}
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(ts.UnixNano(), 10), lokiLine(e)})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"streams": streams})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(pushURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("loki push: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// lokiLine formats the prefix, caller, message, and fields of an entry as
+// logfmt.
+func lokiLine(e LogEntry) string {
+	var b bytes.Buffer
+	enc := logfmt.NewEncoder(&b)
+	kvs := make([]interface{}, 0, len(e.Fields)+6)
+	if e.Prefix != "" {
+		kvs = append(kvs, PrefixKey, e.Prefix)
+	}
+	if e.Caller != "" {
+		kvs = append(kvs, CallerKey, e.Caller)
+	}
+	if e.Message != "" {
+		kvs = append(kvs, MessageKey, e.Message)
+	}
+	kvs = append(kvs, e.Fields...)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		err := enc.EncodeKeyval(kvs[i], kvs[i+1])
+		if err != nil && errors.Is(err, logfmt.ErrUnsupportedValueType) {
+			_ = enc.EncodeKeyval(kvs[i], fmt.Sprintf("%+v", kvs[i+1]))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLokiWriter(t *testing.T) {
+	var mu sync.Mutex
+	var pushes []map[string][]lokiStream
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string][]lokiStream
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		pushes = append(pushes, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w := NewLokiWriter(srv.URL, map[string]string{"app": "test"}, WithLokiBatchSize(3), WithLokiFlushInterval(time.Hour))
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	l := NewWithOptions(w, Options{
+		ReportTimestamp: true,
+		TimeFunction:    func() time.Time { return ts },
+		TimeFormat:      time.RFC3339,
+		Prefix:          "api",
+	})
+	l.Info("hello", "foo", "bar")
+	l.Error("failed", "err", "boom baz")
+	l.Info("again")
+	require.NoError(t, w.Close())
+
+	nano := strconv.FormatInt(ts.UnixNano(), 10)
+	require.Equal(t, []map[string][]lokiStream{{
+		"streams": {
+			{
+				Stream: map[string]string{"app": "test", "level": "info"},
+				Values: [][2]string{
+					{nano, "prefix=api msg=hello foo=bar"},
+					{nano, "prefix=api msg=again"},
+				},
+			},
+			{
+				Stream: map[string]string{"app": "test", "level": "error"},
+				Values: [][2]string{{nano, `prefix=api msg=failed err="boom baz"`}},
+			},
+		},
+	}}, pushes)
+}
+
+func TestLokiWriter_error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "entry too far behind", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	w := NewLokiWriter(srv.URL, nil, WithLokiBatchSize(1))
+	_, err := w.Write([]byte("INFO hello\n"))
+	require.EqualError(t, err, "loki push: 400 Bad Request: entry too far behind")
+	require.NoError(t, w.Close())
+}
@@ -0,0 +1,71 @@
+package log
+
+import (
+	"net/http"
+	"time"
+)
+
+// Middleware returns an HTTP middleware that logs requests.
+//
+// Each request gets its own logger, derived from l, with the request method,
+// path, and remote address attached. The logger is stored in the request
+// context and can be retrieved by handlers with FromContext. Once the request
+// has been handled, a summary entry is logged with the response status, the
+// request duration, and the number of bytes written.
+func Middleware(l *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rl := l.With(
+				"method", r.Method,
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr,
+			)
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(WithContext(r.Context(), rl)))
+			rl.Info("request",
+				"status", rw.status,
+				"duration", time.Since(start),
+				"bytes_written", rw.written,
+			)
+		})
+	}
+}
+
+// responseWriter records the status and the number of bytes written by an
+// HTTP handler.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(p)
+	w.written += n
+	return n, err
+}
+
+// Flush implements http.Flusher.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,48 @@
+package log
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected string
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				FromContext(r.Context()).Info("handling")
+				_, _ = w.Write([]byte("hello"))
+			},
+			expected: "INFO handling method=GET path=/foo remote_addr=192.0.2.1:1234\n" +
+				"INFO request method=GET path=/foo remote_addr=192.0.2.1:1234 status=200 duration=<d> bytes_written=5\n",
+		},
+		{
+			name: "status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expected: "INFO request method=GET path=/foo remote_addr=192.0.2.1:1234 status=404 duration=<d> bytes_written=0\n",
+		},
+	}
+	durRe := regexp.MustCompile(`duration=\S+`)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf)
+			h := Middleware(l)(c.handler)
+			req := httptest.NewRequest(http.MethodGet, "/foo?bar=baz", nil)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			require.Equal(t, c.expected, durRe.ReplaceAllString(buf.String(), "duration=<d>"))
+		})
+	}
+}
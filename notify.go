@@ -0,0 +1,48 @@
+package log
+
+// WithLevelChangeNotifier returns a logger option that calls fn with the old
+// and new levels whenever the logger level is set with SetLevel. It can be
+// used more than once to register several notifiers, which are called in
+// registration order. Notifiers are called outside of the logger lock, so
+// they can safely use the logger.
+func WithLevelChangeNotifier(fn func(old, new Level)) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.levelNotifiers = append(l.levelNotifiers[:len(l.levelNotifiers):len(l.levelNotifiers)], fn)
+	}
+}
+
+// WithWriteNotifier returns a logger option that calls fn with the entry
+// level and the number of bytes written after each successful write to the
+// output. It can be used more than once to register several notifiers, which
+// are called in registration order. Notifiers are called while holding the
+// logger lock, so they must not use the logger.
+func WithWriteNotifier(fn func(level Level, n int)) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.writeNotifiers = append(l.writeNotifiers[:len(l.writeNotifiers):len(l.writeNotifiers)], fn)
+	}
+}
+
+// WithLevelEnabledHook returns a logger option that calls fn with the level
+// of every entry that passes the logger level, before the entry is created
+// or formatted. The entry is dropped if fn returns false. This lets external
+// systems, like feature flags or configuration services, control levels
+// dynamically without calling SetLevel. It can be used more than once, in
+// which case all the hooks must return true. fn must be safe for concurrent
+// use, and is called without holding the logger lock.
+func WithLevelEnabledHook(fn func(level Level) bool) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if prev := l.levelEnabledHook; prev != nil {
+			l.levelEnabledHook = func(level Level) bool {
+				return prev(level) && fn(level)
+			}
+			return
+		}
+		l.levelEnabledHook = fn
+	}
+}
@@ -0,0 +1,87 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelChangeNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	var changes []string
+	notify := func(name string) func(old, new Level) {
+		return func(old, new Level) {
+			changes = append(changes, name+":"+old.String()+"->"+new.String())
+		}
+	}
+	var l *Logger
+	l = New(&buf,
+		WithLevelChangeNotifier(notify("a")),
+		WithLevelChangeNotifier(func(old, new Level) {
+			// Notifiers are called outside of the lock.
+			l.Info("level changed", "old", old, "new", new)
+		}),
+		WithLevelChangeNotifier(notify("b")),
+	)
+	l.SetLevel(DebugLevel)
+	l.SetLevel(WarnLevel)
+	require.Equal(t, []string{"a:info->debug", "b:info->debug", "a:debug->warn", "b:debug->warn"}, changes)
+	require.Equal(t, "INFO level changed old=info new=debug\n", buf.String())
+}
+
+func TestWriteNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	var writes []string
+	notify := func(name string) func(level Level, n int) {
+		return func(level Level, n int) {
+			writes = append(writes, fmt.Sprintf("%s:%s:%d", name, level, n))
+		}
+	}
+	l := New(&buf, WithWriteNotifier(notify("a")), WithWriteNotifier(notify("b")))
+	l.Info("info")
+	l.Debug("debug")
+	l.Error("error", "foo", "bar")
+	require.Equal(t, []string{"a:info:10", "b:info:10", "a:error:19", "b:error:19"}, writes)
+
+	writes = nil
+	l.SetOutput(&failWriter{fail: true})
+	l.Info("info")
+	require.Empty(t, writes)
+}
+
+func TestWithLevelEnabledHook(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []Level
+	verbose := false
+	l := New(&buf, WithLevelEnabledHook(func(level Level) bool {
+		calls = append(calls, level)
+		return level >= WarnLevel || verbose
+	}))
+	l.SetLevel(InfoLevel)
+
+	l.Debug("filtered by level")
+	l.Info("filtered by hook")
+	l.Infof("filtered by hook %d", 1)
+	l.Warn("written")
+	require.Equal(t, "WARN written\n", buf.String())
+	require.Equal(t, []Level{InfoLevel, InfoLevel, WarnLevel}, calls)
+
+	buf.Reset()
+	verbose = true
+	l.Info("written")
+	require.Equal(t, "INFO written\n", buf.String())
+}
+
+func TestWithLevelEnabledHook_multiple(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf,
+		WithLevelEnabledHook(func(level Level) bool { return level != WarnLevel }),
+		WithLevelEnabledHook(func(level Level) bool { return level != ErrorLevel }),
+	)
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	require.Equal(t, "INFO info\n", buf.String())
+}
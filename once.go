@@ -0,0 +1,50 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+)
+
+// onceKey identifies a message logged by a once logger.
+type onceKey struct {
+	level Level
+	msg   string
+}
+
+// Once returns a new logger that logs each unique level and message pair only
+// once, for the lifetime of the logger. Duplicates are silently dropped. This
+// is useful to warn about deprecated behavior or missing configuration
+// without spamming the log.
+//
+// Loggers derived from the returned logger share the same set of seen
+// messages.
+func (l *Logger) Once() *Logger {
+	sl := l.With()
+	sl.once = &sync.Map{}
+	return sl
+}
+
+// Reset clears the set of messages seen by a logger returned by Once, so that
+// they're logged again. It's a no-op for other loggers.
+func (l *Logger) Reset() {
+	if l.once == nil {
+		return
+	}
+	l.once.Range(func(key, _ interface{}) bool {
+		l.once.Delete(key)
+		return true
+	})
+}
+
+// forget removes the message logged at level from the messages seen by a
+// once logger, after its entry was dropped.
+func (l *Logger) forget(level Level, msg interface{}) {
+	l.once.Delete(onceKey{level, fmt.Sprint(msg)})
+}
+
+// seen returns true if the message was already logged at level by a once
+// logger.
+func (l *Logger) seen(level Level, msg interface{}) bool {
+	_, loaded := l.once.LoadOrStore(onceKey{level, fmt.Sprint(msg)}, struct{}{})
+	return loaded
+}
@@ -0,0 +1,64 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnce(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).Once()
+	l.Warn("deprecated", "foo", "bar")
+	l.Warn("deprecated", "foo", "baz")
+	l.With("sub", true).Warn("deprecated")
+	l.Error("deprecated")
+	l.Warn("missing config")
+	require.Equal(t, "WARN deprecated foo=bar\nERRO deprecated\nWARN missing config\n", buf.String())
+
+	buf.Reset()
+	l.Reset()
+	l.Warn("deprecated")
+	l.Warn("deprecated")
+	require.Equal(t, "WARN deprecated\n", buf.String())
+}
+
+func TestOnce_parent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	once := l.Once()
+	once.Info("info")
+	l.Info("info")
+	l.Info("info")
+	l.Reset()
+	require.Equal(t, "INFO info\nINFO info\nINFO info\n", buf.String())
+}
+
+func TestOnce_dropped(t *testing.T) {
+	var buf bytes.Buffer
+	drop := true
+	l := New(&buf, WithPipeline(NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		if drop {
+			return nil
+		}
+		return e
+	})))).Once()
+	l.Warn("deprecated")
+	drop = false
+	l.Warn("deprecated")
+	l.Warn("deprecated")
+	require.Equal(t, "WARN deprecated\n", buf.String())
+}
+
+func TestOnce_rateLimited(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithMaxLogRate(1, time.Hour)).Once()
+	l.Info("first")
+	l.Warn("deprecated")
+	l.rateLimiter = nil
+	l.Warn("deprecated")
+	l.Warn("deprecated")
+	require.Equal(t, "INFO first\nWARN deprecated\n", buf.String())
+}
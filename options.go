@@ -2,6 +2,7 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -36,6 +37,12 @@ func LongCallerFormatter(file string, line int, funcName string) string {
 	return fmt.Sprintf("%s:%d", file, line)
 }
 
+// FullCallerFormatter is a caller formatter that returns the last 2 levels of
+// the path, the line number, and the function name.
+func FullCallerFormatter(file string, line int, funcName string) string {
+	return fmt.Sprintf("%s:%d:%s", trimCallerPath(file, 2), line, trimFuncName(funcName))
+}
+
 // Options is the options for the logger.
 type Options struct {
 	// TimeFunction is the time function for the logger. The default is time.Now.
@@ -57,3 +64,70 @@ type Options struct {
 	// Formatter is the formatter for the logger. The default is TextFormatter.
 	Formatter Formatter
 }
+
+// WithWriter returns a logger option that sets the output destination.
+func WithWriter(w io.Writer) LoggerOption {
+	return func(l *Logger) {
+		l.SetOutput(w)
+	}
+}
+
+// WithMessagePrefix returns a logger option that prepends prefix to every
+// message. Unlike the logger prefix, it's part of the message itself.
+func WithMessagePrefix(prefix string) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.msgPrefix = prefix
+	}
+}
+
+// WithMessageSuffix returns a logger option that appends suffix to every
+// message. Entries logged without a message are left untouched.
+func WithMessageSuffix(suffix string) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.msgSuffix = suffix
+	}
+}
+
+// WithMinMessageLength returns a logger option that pads messages with
+// trailing spaces to at least n characters, so that the fields of
+// consecutive entries line up. Messages of entries without fields aren't
+// padded. It only applies to the text formatter.
+func WithMinMessageLength(n int) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.minMsgLen = n
+	}
+}
+
+// WithElideDuplicateTimestamps returns a logger option that replaces the
+// timestamp of an entry with a continuation marker when it's identical to the
+// timestamp of the previous entry, like dmesg does. It only applies to the
+// text formatter.
+func WithElideDuplicateTimestamps(elide bool) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.elideTimestamps = elide
+	}
+}
+
+// WithCallerDepth returns a logger option that sets the number of additional
+// stack frames to skip when reporting the caller location.
+func WithCallerDepth(depth int) LoggerOption {
+	return func(l *Logger) {
+		l.SetCallerDepth(depth)
+	}
+}
+
+// WithCallerOffset returns a logger option that sets the number of additional
+// stack frames to skip when reporting the caller location.
+//
+// Deprecated: use WithCallerDepth instead.
+func WithCallerOffset(n int) LoggerOption {
+	return WithCallerDepth(n)
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -69,3 +71,86 @@ func TestCallerFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestWithWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(ioutil.Discard, WithWriter(&buf))
+	require.Equal(t, &buf, l.GetWriter())
+	l.Info("info")
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+func TestWithMessagePrefix(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithMessagePrefix("[REQUEST] "))
+	l.SetPrefix("app")
+	l.Info("started", "foo", "bar")
+	l.Printf("%d requests", 2)
+	l.Print(nil, "foo", "bar")
+	require.Equal(t, "INFO app: [REQUEST] started foo=bar\napp: [REQUEST] 2 requests\napp:  foo=bar\n", buf.String())
+}
+
+func TestWithMessageSuffix(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithMessagePrefix("["), WithMessageSuffix(" (beta)"))
+	l.Info("started", "foo", "bar")
+	l.Print(nil, "foo", "bar")
+	require.Equal(t, "INFO [started (beta) foo=bar\n foo=bar\n", buf.String())
+}
+
+func TestWithCallerDepth(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithCallerDepth(1))
+	l.SetReportCaller(true)
+	_, _, wline, _ := runtime.Caller(0)
+	wrapper := func(msg string) { l.Info(msg) }
+	_, _, line, _ := runtime.Caller(0)
+	wrapper("hi")
+	require.Equal(t, fmt.Sprintf("INFO <log/options_test.go:%d> hi\n", line+1), buf.String())
+
+	buf.Reset()
+	l.SetCallerDepth(0)
+	wrapper("hi")
+	require.Equal(t, fmt.Sprintf("INFO <log/options_test.go:%d> hi\n", wline+1), buf.String())
+}
+
+func TestWithMinMessageLength(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithMinMessageLength(8))
+	l.Info("start", "foo", "bar")
+	l.Info("finished", "foo", "bar")
+	l.Info("longer message", "foo", "bar")
+	l.Info("short")
+	expected := "INFO start    foo=bar\n" +
+		"INFO finished foo=bar\n" +
+		"INFO longer message foo=bar\n" +
+		"INFO short\n"
+	require.Equal(t, expected, buf.String())
+}
+
+func TestWithElideDuplicateTimestamps(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := New(&buf, WithElideDuplicateTimestamps(true))
+	l.SetReportTimestamp(true)
+	l.SetTimeFunction(func() time.Time { return now })
+	l.SetTimeFormat(time.Kitchen)
+	l.Info("first")
+	l.Info("second")
+	require.Equal(t, "12:00PM INFO peek\n", string(l.Formatted(InfoLevel, "peek")))
+	now = now.Add(time.Minute)
+	l.Info("third")
+	l.Info("fourth")
+	expected := "12:00PM INFO first\n" +
+		"      │ INFO second\n" +
+		"12:01PM INFO third\n" +
+		"      │ INFO fourth\n"
+	require.Equal(t, expected, buf.String())
+}
+
+func TestWithCallerOffset(t *testing.T) {
+	l := New(ioutil.Discard, WithCallerOffset(2))
+	require.Equal(t, int32(2), l.callerOffset)
+	l.SetCallerOffset(1)
+	require.Equal(t, int32(1), l.callerOffset)
+}
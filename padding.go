@@ -0,0 +1,50 @@
+package log
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithPadding returns a logger option that right-pads the levels of the text
+// formatter to the same width, so that the columns following them line up.
+// The width is the widest of the styles of the built-in levels and of the
+// levels the logger has a style for, and grows when a wider level is written.
+func WithPadding() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.levelPadding = true
+		l.resetLevelWidth()
+	}
+}
+
+// resetLevelWidth computes the padded width of the levels again, after the
+// level styles changed. It must be called with the lock held.
+func (l *Logger) resetLevelWidth() {
+	if !l.levelPadding {
+		return
+	}
+	atomic.StoreInt32(&l.levelWidth, 0)
+	for level := DebugLevel; level <= FatalLevel; level++ {
+		l.padLevel(l.levelStyle(level).Renderer(l.re).String())
+	}
+	for level := range l.levelStyles {
+		l.padLevel(l.levelStyle(level).Renderer(l.re).String())
+	}
+}
+
+// padLevel returns the rendered level lvl, right-padded to the width of the
+// widest level so far.
+func (l *Logger) padLevel(lvl string) string {
+	w := int32(lipgloss.Width(lvl))
+	width := atomic.LoadInt32(&l.levelWidth)
+	for w > width && !atomic.CompareAndSwapInt32(&l.levelWidth, width, w) {
+		width = atomic.LoadInt32(&l.levelWidth)
+	}
+	if w >= width {
+		return lvl
+	}
+	return lvl + strings.Repeat(" ", int(width-w))
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPadding(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithPadding())
+	l.SetLevel(DebugLevel)
+	l.Debug("debug")
+	l.Info("info")
+	l.Print("print")
+	require.Equal(t, "DEBU debug\nINFO info\nprint\n", buf.String())
+}
+
+func TestWithPadding_customLevels(t *testing.T) {
+	info, warn := InfoLevelStyle, WarnLevelStyle
+	defer func() { InfoLevelStyle, WarnLevelStyle = info, warn }()
+	InfoLevelStyle = InfoLevelStyle.Copy().SetString("INFO").MaxWidth(5)
+	WarnLevelStyle = WarnLevelStyle.Copy().SetString("WARNING").MaxWidth(7)
+
+	var buf bytes.Buffer
+	l := New(&buf, WithPadding())
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Equal(t, []string{
+		"INFO    info",
+		"WARNING warn",
+		"ERRO    error",
+	}, lines)
+}
+
+func TestWithPadding_levelStyles(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.levelStyles = map[Level]lipgloss.Style{FatalLevel + 2: InfoLevelStyle.Copy().SetString("NOTICE").MaxWidth(6)}
+	WithPadding()(l)
+	l.Info("before")
+	l.Log(FatalLevel+2, "notice")
+	l.Info("after")
+	require.Equal(t, "INFO   before\nNOTICE notice\nINFO   after\n", buf.String())
+}
+
+func TestWithPadding_theme(t *testing.T) {
+	warn := WarnLevelStyle
+	defer func() { WarnLevelStyle = warn }()
+	WarnLevelStyle = WarnLevelStyle.Copy().SetString("WARNING").MaxWidth(7)
+
+	var buf bytes.Buffer
+	l := New(&buf, WithPadding(), WithANSI256Theme())
+	l.Info("info")
+	l.Warn("warn")
+	require.Equal(t, "INFO info\nWARN warn\n", buf.String())
+}
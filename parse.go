@@ -0,0 +1,381 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidEntry is returned when parsing a malformed log entry.
+var ErrInvalidEntry = errors.New("invalid log entry")
+
+// parseTimeFormats are the timestamp layouts recognized by Parse.
+var parseTimeFormats = []string{
+	DefaultTimeFormat,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	time.Kitchen,
+}
+
+// parseLevels maps the text representation of levels to levels.
+var parseLevels = map[string]Level{
+	strings.ToUpper(DebugLevel.String())[:4]: DebugLevel,
+	strings.ToUpper(InfoLevel.String())[:4]:  InfoLevel,
+	strings.ToUpper(WarnLevel.String())[:4]:  WarnLevel,
+	strings.ToUpper(ErrorLevel.String())[:4]: ErrorLevel,
+	strings.ToUpper(FatalLevel.String())[:4]: FatalLevel,
+}
+
+// Parse reads log entries written with the text formatter from r, without
+// colors, and parses them back into log entries. Field keys and values are
+// parsed as strings, and multi-line and quoted values are restored.
+//
+// Timestamps are recognized if they use the default time format, RFC 3339,
+// "2006-01-02 15:04:05", or time.Kitchen. Elided duplicate timestamps are
+// restored from the previous entry. Since messages aren't quoted, a message
+// that ends with something that looks like fields, or starts with a word
+// ending with a colon, is ambiguous: the former is parsed as fields, and the
+// latter as the prefix.
+func Parse(r io.Reader) ([]LogEntry, error) {
+	var entries []LogEntry
+	err := ParseFunc(r, func(e LogEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+// ParseFunc is like Parse, but calls fn with each entry as soon as it's
+// complete, that is once the first line of the next entry, or the end of r,
+// is read. It's meant for following a log as it's written. An error returned
+// by fn stops parsing and is returned.
+func ParseFunc(r io.Reader, fn func(LogEntry) error) error {
+	var lines []string
+	var last time.Time
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		e, err := parseEntry(lines, last)
+		if err != nil {
+			return err
+		}
+		last = e.Time
+		lines = lines[:0]
+		return fn(e)
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for s.Scan() {
+		line := s.Text()
+		if len(lines) > 0 && isContinuation(line) {
+			lines = append(lines, line)
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// ParseJSON reads newline-delimited log entries written with the JSON
+// formatter from r, and parses them back into log entries. The timestamp,
+// level, caller, prefix, and message are restored from their keys, and any
+// other key is stored in the entry fields, sorted by key. Integers are
+// parsed as int64, and other numbers as float64. A timestamp or level that can't be parsed is kept as a
+// field.
+func ParseJSON(r io.Reader) ([]LogEntry, error) {
+	var entries []LogEntry
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	for {
+		var m map[string]interface{}
+		if err := d.Decode(&m); err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return entries, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+		}
+		entries = append(entries, jsonEntry(m))
+	}
+}
+
+// jsonEntry converts a decoded JSON object into a log entry.
+func jsonEntry(m map[string]interface{}) LogEntry {
+	e := LogEntry{Level: noLevel}
+	if ts, ok := m[TimestampKey].(string); ok {
+		if t, rest, ok := parseTimestamp(ts); ok && rest == "" {
+			e.Time = t
+			delete(m, TimestampKey)
+		}
+	}
+	if lvl, ok := m[LevelKey].(string); ok {
+		for _, level := range parseLevels {
+			if level.String() == lvl {
+				e.Level = level
+				delete(m, LevelKey)
+				break
+			}
+		}
+	}
+	if caller, ok := m[CallerKey].(string); ok {
+		e.Caller = caller
+		delete(m, CallerKey)
+	}
+	if prefix, ok := m[PrefixKey].(string); ok {
+		e.Prefix = strings.TrimSuffix(prefix, ":")
+		delete(m, PrefixKey)
+	}
+	if msg, ok := m[MessageKey].(string); ok {
+		e.Message = msg
+		e.hasMessage = true
+		delete(m, MessageKey)
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := m[k]
+		if n, ok := v.(json.Number); ok {
+			v = jsonNumber(n)
+		}
+		e.Fields = append(e.Fields, k, v)
+	}
+	return e
+}
+
+// jsonNumber converts a JSON number to an int64 if it's an integer, or to a
+// float64 otherwise.
+func jsonNumber(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// isContinuation returns true if line continues the previous entry, as part
+// of multi-line fields.
+func isContinuation(line string) bool {
+	if line == " " || strings.HasPrefix(line, indentSeparator) {
+		return true
+	}
+	return strings.HasPrefix(line, "  ") && len(line) > 2 && line[2] != ' '
+}
+
+// parseEntry parses the lines of a single entry. last is the timestamp of
+// the previous entry, used to restore elided timestamps.
+func parseEntry(lines []string, last time.Time) (LogEntry, error) {
+	var e LogEntry
+	e.Level = noLevel
+	line := lines[0]
+
+	if trimmed := strings.TrimLeft(line, " "); strings.HasPrefix(trimmed, elidedSeparator+" ") {
+		e.Time = last
+		line = strings.TrimPrefix(trimmed, elidedSeparator+" ")
+	} else if t, rest, ok := parseTimestamp(line); ok {
+		e.Time = t
+		line = rest
+	}
+
+	if len(line) >= 4 {
+		if level, ok := parseLevels[line[:4]]; ok && (len(line) == 4 || line[4] == ' ') {
+			e.Level = level
+			line = strings.TrimPrefix(line[4:], " ")
+		}
+	}
+
+	if strings.HasPrefix(line, "<") {
+		if i := strings.Index(line, "> "); i >= 0 {
+			e.Caller = line[1:i]
+			line = line[i+2:]
+		} else if strings.HasSuffix(line, ">") {
+			e.Caller = line[1 : len(line)-1]
+			line = ""
+		}
+	}
+
+	if i := strings.IndexByte(line, ' '); i > 0 && line[i-1] == ':' {
+		e.Prefix = line[:i-1]
+		line = line[i+1:]
+	} else if strings.HasSuffix(line, ":") && !strings.Contains(line, " ") {
+		e.Prefix = line[:len(line)-1]
+		line = ""
+	}
+
+	// The message is the shortest leading part of the line that leaves only
+	// fields.
+	rest := strings.Join(append([]string{""}, lines[1:]...), "\n")
+	for i := 0; i <= len(line); i++ {
+		if i < len(line) && line[i] != ' ' {
+			continue
+		}
+		fields, err := parseFields(line[i:] + rest)
+		if err != nil {
+			continue
+		}
+		e.Message = line[:i]
+		e.Fields = fields
+		e.hasMessage = e.Message != ""
+		return e, nil
+	}
+	return e, fmt.Errorf("%w: %q", ErrInvalidEntry, strings.Join(lines, "\n"))
+}
+
+// parseTimestamp parses a timestamp at the beginning of line, and returns the
+// rest of the line.
+func parseTimestamp(line string) (time.Time, string, bool) {
+	for _, layout := range parseTimeFormats {
+		n := strings.Count(layout, " ") + 1
+		parts := strings.SplitN(line, " ", n+1)
+		if len(parts) < n {
+			continue
+		}
+		t, err := time.Parse(layout, strings.Join(parts[:n], " "))
+		if err != nil {
+			continue
+		}
+		if len(parts) > n {
+			return t, parts[n], true
+		}
+		return t, "", true
+	}
+	return time.Time{}, "", false
+}
+
+// parseFields parses the key-value pairs written by the text formatter.
+func parseFields(s string) ([]interface{}, error) {
+	var fields []interface{}
+	for {
+		s = strings.TrimLeft(s, " \n")
+		if s == "" {
+			return fields, nil
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || strings.ContainsAny(s[:eq], " \n\"") {
+			return nil, ErrInvalidEntry
+		}
+		key := s[:eq]
+		s = s[eq+1:]
+
+		var val string
+		switch {
+		case strings.HasPrefix(s, "\n"+indentSeparator):
+			var vals []string
+			for strings.HasPrefix(s, "\n"+indentSeparator) {
+				s = s[len(indentSeparator)+1:]
+				end := strings.IndexByte(s, '\n')
+				if end < 0 {
+					end = len(s)
+				}
+				vals = append(vals, unescapeString(s[:end]))
+				s = s[end:]
+			}
+			val = strings.Join(vals, "\n")
+		case strings.HasPrefix(s, `"`):
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, ErrInvalidEntry
+			}
+			val = unescapeString(s[1:end])
+			s = s[end+1:]
+			if s != "" && s[0] != ' ' && s[0] != '\n' {
+				return nil, ErrInvalidEntry
+			}
+		default:
+			end := strings.IndexAny(s, " \n")
+			if end < 0 {
+				end = len(s)
+			}
+			val = s[:end]
+			s = s[end:]
+		}
+		fields = append(fields, key, val)
+	}
+}
+
+// closingQuote returns the index of the quote closing the quoted string at
+// the beginning of s, or -1 if there's none.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeString reverses escapeStringForOutput. Unknown escape sequences are
+// left as is.
+func unescapeString(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i == len(s)-1 {
+			b.WriteByte(c)
+			continue
+		}
+		size := 0
+		switch s[i+1] {
+		case 'x':
+			size = 2
+		case 'u':
+			size = 4
+		case 'U':
+			size = 8
+		}
+		if size > 0 && i+2+size <= len(s) {
+			if r, err := strconv.ParseUint(s[i+2:i+2+size], 16, 32); err == nil {
+				b.WriteRune(rune(r))
+				i += 1 + size
+				continue
+			}
+		}
+		if r, ok := escapeRunes[s[i+1]]; ok {
+			b.WriteRune(r)
+			i++
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeRunes maps single character escape sequences to the runes they
+// represent.
+var escapeRunes = map[byte]rune{
+	'a': '\a',
+	'b': '\b',
+	'f': '\f',
+	'n': '\n',
+	'r': '\r',
+	't': '\t',
+	'v': '\v',
+	'"': '"',
+}
@@ -0,0 +1,182 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	cases := []struct {
+		name     string
+		input    string
+		expected []LogEntry
+	}{
+		{
+			name:  "simple",
+			input: "INFO hello world foo=bar\n",
+			expected: []LogEntry{
+				{Level: InfoLevel, Message: "hello world", Fields: []interface{}{"foo", "bar"}, hasMessage: true},
+			},
+		},
+		{
+			name:  "full header",
+			input: "2023/04/05 06:07:08 WARN <main.go:42> app: careful\n",
+			expected: []LogEntry{
+				{Time: ts, Level: WarnLevel, Caller: "main.go:42", Prefix: "app", Message: "careful", hasMessage: true},
+			},
+		},
+		{
+			name:  "no level",
+			input: "printed x=1\n",
+			expected: []LogEntry{
+				{Level: noLevel, Message: "printed", Fields: []interface{}{"x", "1"}, hasMessage: true},
+			},
+		},
+		{
+			name:  "no message",
+			input: "ERRO  err=\"boom \\\"bang\\\"\"\n",
+			expected: []LogEntry{
+				{Level: ErrorLevel, Fields: []interface{}{"err", `boom "bang"`}},
+			},
+		},
+		{
+			name:  "multi-line",
+			input: "ERRO failed\n  stack=\n  │ line1\n  │ line2\n  after=x\nINFO next\n",
+			expected: []LogEntry{
+				{Level: ErrorLevel, Message: "failed", Fields: []interface{}{"stack", "line1\nline2", "after", "x"}, hasMessage: true},
+				{Level: InfoLevel, Message: "next", hasMessage: true},
+			},
+		},
+		{
+			name:  "elided timestamp",
+			input: "2023/04/05 06:07:08 INFO first\n                  │ INFO second\n",
+			expected: []LogEntry{
+				{Time: ts, Level: InfoLevel, Message: "first", hasMessage: true},
+				{Time: ts, Level: InfoLevel, Message: "second", hasMessage: true},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entries, err := Parse(strings.NewReader(c.input))
+			require.NoError(t, err)
+			require.Equal(t, c.expected, entries)
+		})
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("INFO msg\n  │ orphan\n"))
+	require.ErrorIs(t, err, ErrInvalidEntry)
+}
+
+func TestParseFunc(t *testing.T) {
+	var msgs []string
+	err := ParseFunc(strings.NewReader("INFO one\n  err=\n  │ a\n  │ b\nWARN two\nERRO three\n"), func(e LogEntry) error {
+		msgs = append(msgs, e.Message)
+		if e.Level == WarnLevel {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "stop")
+	require.Equal(t, []string{"one", "two"}, msgs)
+}
+
+func TestParse_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{
+		ReportTimestamp: true,
+		ReportCaller:    true,
+		CallerFormatter: func(string, int, string) string { return "main.go:42" },
+		TimeFunction: func() time.Time {
+			return time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+		},
+		Level: DebugLevel,
+	})
+	l.Info("hello world", "foo", "bar", "count", 42)
+	l.Warn("quoted", "msg", `hello "world"`, "empty", "", "tab", "a\tb")
+	l.Error("multi", "stack", "line1\nline2", "after", "x")
+	l.Debug("two multi", "a", "1\n2", "b", "3\n4")
+	l.Print("no level")
+	l.Info(nil, "only", "fields")
+	l.WithPrefix("app").Info("prefixed", "url", "http://example.com/?q=1")
+	l.Error("trailing multi", "a", "1\n2")
+
+	out := buf.String()
+	entries, err := Parse(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, entries, 8)
+
+	var got bytes.Buffer
+	for i := range entries {
+		l.format(&got, &entries[i])
+	}
+	require.Equal(t, out, got.String())
+}
+
+func TestParseJSON(t *testing.T) {
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	input := `{"caller":"main.go:42","foo":"bar","lvl":"info","msg":"hello","n":42,"prefix":"app:","ts":"2023/04/05 06:07:08"}
+{"lvl":"custom","msg":"unknown level","ts":"yesterday"}
+
+{"only":true}
+`
+	entries, err := ParseJSON(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []LogEntry{
+		{
+			Time:       ts,
+			Level:      InfoLevel,
+			Caller:     "main.go:42",
+			Prefix:     "app",
+			Message:    "hello",
+			Fields:     []interface{}{"foo", "bar", "n", int64(42)},
+			hasMessage: true,
+		},
+		{
+			Level:      noLevel,
+			Message:    "unknown level",
+			Fields:     []interface{}{"lvl", "custom", "ts", "yesterday"},
+			hasMessage: true,
+		},
+		{
+			Level:  noLevel,
+			Fields: []interface{}{"only", true},
+		},
+	}, entries)
+
+	_, err = ParseJSON(strings.NewReader(`{"msg":"ok"}` + "\n{bad\n"))
+	require.ErrorIs(t, err, ErrInvalidEntry)
+}
+
+func TestParseJSON_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{
+		Formatter:       JSONFormatter,
+		ReportTimestamp: true,
+		TimeFunction: func() time.Time {
+			return time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+		},
+	})
+	l.Info("hello", "foo", "bar", "count", 42)
+	l.WithPrefix("app").Error("failed", "err", "boom")
+	l.Print("no level")
+
+	out := buf.String()
+	entries, err := ParseJSON(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	var got bytes.Buffer
+	for i := range entries {
+		l.format(&got, &entries[i])
+	}
+	require.Equal(t, out, got.String())
+}
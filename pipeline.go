@@ -0,0 +1,61 @@
+package log
+
+// Transformer transforms log entries before they're formatted. Returning nil
+// drops the entry.
+type Transformer interface {
+	Transform(e *LogEntry) *LogEntry
+}
+
+// TransformerFunc is a function that implements Transformer.
+type TransformerFunc func(e *LogEntry) *LogEntry
+
+// Transform implements Transformer.
+func (f TransformerFunc) Transform(e *LogEntry) *LogEntry {
+	return f(e)
+}
+
+// Pipeline is an ordered list of transformers. Transformers can add fields,
+// redact values, or drop entries.
+//
+// A pipeline must not be modified once it's in use by a logger.
+type Pipeline struct {
+	transformers []Transformer
+}
+
+// NewPipeline returns a new pipeline with the given transformers.
+func NewPipeline(transformers ...Transformer) *Pipeline {
+	return &Pipeline{transformers: transformers}
+}
+
+// Add appends a transformer to the pipeline and returns the pipeline.
+func (p *Pipeline) Add(t Transformer) *Pipeline {
+	p.transformers = append(p.transformers, t)
+	return p
+}
+
+// AddFunc appends a transformer function to the pipeline and returns the
+// pipeline.
+func (p *Pipeline) AddFunc(f func(e *LogEntry) *LogEntry) *Pipeline {
+	return p.Add(TransformerFunc(f))
+}
+
+// Transform implements Transformer. It applies the transformers in order and
+// stops as soon as one of them drops the entry.
+func (p *Pipeline) Transform(e *LogEntry) *LogEntry {
+	for _, t := range p.transformers {
+		if e = t.Transform(e); e == nil {
+			return nil
+		}
+	}
+	return e
+}
+
+// WithPipeline returns a logger option that applies p to every log entry
+// before it's formatted.
+func WithPipeline(p *Pipeline) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.pipeline = p
+	}
+}
@@ -0,0 +1,78 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPipeline().
+		AddFunc(func(e *LogEntry) *LogEntry {
+			if strings.HasPrefix(e.Message, "drop") {
+				return nil
+			}
+			return e
+		}).
+		AddFunc(func(e *LogEntry) *LogEntry {
+			for i := 0; i < len(e.Fields); i += 2 {
+				if e.Fields[i] == "password" {
+					e.Fields[i+1] = "REDACTED"
+				}
+			}
+			return e
+		}).
+		AddFunc(func(e *LogEntry) *LogEntry {
+			e.Fields = append(e.Fields, "env", "test")
+			return e
+		})
+	l := New(&buf, WithPipeline(p))
+	cases := []struct {
+		name     string
+		expected string
+		msg      string
+		kvs      []interface{}
+	}{
+		{
+			name:     "add field",
+			expected: "INFO info env=test\n",
+			msg:      "info",
+		},
+		{
+			name:     "redact",
+			expected: "INFO login user=foo password=REDACTED env=test\n",
+			msg:      "login",
+			kvs:      []interface{}{"user", "foo", "password", "hunter2"},
+		},
+		{
+			name:     "drop",
+			expected: "",
+			msg:      "drop me",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			l.Info(c.msg, c.kvs...)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestPipeline_level(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		e.Level = ErrorLevel
+		e.Message = strings.ToUpper(e.Message)
+		return e
+	}))
+	l := New(&buf, WithPipeline(p))
+	l.Info("info")
+	require.Equal(t, "ERRO INFO\n", buf.String())
+	require.Nil(t, New(&buf, WithPipeline(NewPipeline(TransformerFunc(func(*LogEntry) *LogEntry {
+		return nil
+	})))).Formatted(InfoLevel, "info"))
+}
@@ -29,16 +29,18 @@ func SetDefault(logger *Logger) {
 }
 
 // New returns a new logger with the default options.
-func New(w io.Writer) *Logger {
-	return NewWithOptions(w, Options{})
+func New(w io.Writer, opts ...LoggerOption) *Logger {
+	return NewWithOptions(w, Options{}, opts...)
 }
 
-// NewWithOptions returns a new logger using the provided options.
-func NewWithOptions(w io.Writer, o Options) *Logger {
+// NewWithOptions returns a new logger using the provided options. Logger
+// options are applied last, in order.
+func NewWithOptions(w io.Writer, o Options, opts ...LoggerOption) *Logger {
 	l := &Logger{
 		b:               bytes.Buffer{},
 		mu:              &sync.RWMutex{},
 		helpers:         &sync.Map{},
+		counters:        &levelCounters{},
 		level:           int32(o.Level),
 		reportTimestamp: o.ReportTimestamp,
 		reportCaller:    o.ReportCaller,
@@ -65,6 +67,10 @@ func NewWithOptions(w io.Writer, o Options) *Logger {
 		l.timeFormat = DefaultTimeFormat
 	}
 
+	for _, opt := range opts {
+		opt(l)
+	}
+
 	return l
 }
 
@@ -78,6 +84,23 @@ func SetReportCaller(report bool) {
 	defaultLogger.SetReportCaller(report)
 }
 
+// ErrLevel returns the level err should be logged at by the default logger.
+func ErrLevel(err error) Level {
+	return defaultLogger.ErrLevel(err)
+}
+
+// SetCallerDepth sets the caller depth for the default logger.
+func SetCallerDepth(depth int) {
+	defaultLogger.SetCallerDepth(depth)
+}
+
+// SetCallerOffset sets the caller offset for the default logger.
+//
+// Deprecated: use SetCallerDepth instead.
+func SetCallerOffset(n int) {
+	defaultLogger.SetCallerDepth(n)
+}
+
 // SetLevel sets the level for the default logger.
 func SetLevel(level Level) {
 	defaultLogger.SetLevel(level)
@@ -103,6 +126,27 @@ func SetOutput(w io.Writer) {
 	defaultLogger.SetOutput(w)
 }
 
+// SetWriter sets the output for the default logger. It's an alias for
+// SetOutput.
+func SetWriter(w io.Writer) {
+	defaultLogger.SetWriter(w)
+}
+
+// GetWriter returns the output of the default logger.
+func GetWriter() io.Writer {
+	return defaultLogger.GetWriter()
+}
+
+// Rotate rotates the output of the default logger if it supports rotation.
+func Rotate() error {
+	return defaultLogger.Rotate()
+}
+
+// Flush flushes the output of the default logger if it buffers writes.
+func Flush() error {
+	return defaultLogger.Flush()
+}
+
 // SetFormatter sets the formatter for the default logger.
 func SetFormatter(f Formatter) {
 	defaultLogger.SetFormatter(f)
@@ -133,6 +177,12 @@ func WithPrefix(prefix string) *Logger {
 	return defaultLogger.WithPrefix(prefix)
 }
 
+// WithGroup returns a new logger that qualifies the keys of the fields added
+// afterwards with name, see Logger.WithGroup.
+func WithGroup(name string) *Logger {
+	return defaultLogger.WithGroup(name)
+}
+
 // Helper marks the calling function as a helper
 // and skips it for source location information.
 // It's the equivalent of testing.TB.Helper().
@@ -141,6 +191,72 @@ func Helper() {
 	defaultLogger.helper(1)
 }
 
+// Log logs a message at the given level. Logging at FatalLevel exits the
+// program, like Fatal.
+func Log(level Level, msg interface{}, keyvals ...interface{}) {
+	defaultLogger.log(level, msg, keyvals...)
+	if level == FatalLevel {
+		defaultLogger.exit(msg)
+	}
+}
+
+// LogIf logs a message at the given level if cond is true, and returns
+// cond, like WarnIf. Logging at FatalLevel exits the program, like Fatal.
+func LogIf(cond bool, level Level, msg interface{}, keyvals ...interface{}) bool {
+	if !cond {
+		return false
+	}
+	defaultLogger.log(level, msg, keyvals...)
+	if level == FatalLevel {
+		defaultLogger.exit(msg)
+	}
+	return true
+}
+
+// DebugIf logs a debug message if cond is true, and returns cond, like
+// WarnIf.
+func DebugIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		defaultLogger.log(DebugLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// InfoIf logs an info message if cond is true, and returns cond, like
+// WarnIf.
+func InfoIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		defaultLogger.log(InfoLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// WarnIf logs a warning message if cond is true. It returns cond, so that
+// it can be used as a condition:
+//
+//	if log.WarnIf(err != nil, "cleanup failed", "err", err) {
+//		// ...
+//	}
+func WarnIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		defaultLogger.log(WarnLevel, msg, keyvals...)
+	}
+	return cond
+}
+
+// ErrorIf logs an error message if cond is true. It returns cond, so that
+// it can be used as a condition:
+//
+//	if log.ErrorIf(err != nil, "request failed", "err", err) {
+//		return err
+//	}
+func ErrorIf(cond bool, msg interface{}, keyvals ...interface{}) bool {
+	if cond {
+		defaultLogger.log(ErrorLevel, msg, keyvals...)
+	}
+	return cond
+}
+
 // Debug logs a debug message.
 func Debug(msg interface{}, keyvals ...interface{}) {
 	defaultLogger.log(DebugLevel, msg, keyvals...)
@@ -164,7 +280,7 @@ func Error(msg interface{}, keyvals ...interface{}) {
 // Fatal logs a fatal message and exit.
 func Fatal(msg interface{}, keyvals ...interface{}) {
 	defaultLogger.log(FatalLevel, msg, keyvals...)
-	os.Exit(1)
+	defaultLogger.exit(msg)
 }
 
 // Print logs a message with no level.
@@ -174,28 +290,41 @@ func Print(msg interface{}, keyvals ...interface{}) {
 
 // Debugf logs a debug message with formatting.
 func Debugf(format string, args ...interface{}) {
+	if defaultLogger.skipFormat(DebugLevel) {
+		return
+	}
 	defaultLogger.log(DebugLevel, fmt.Sprintf(format, args...))
 }
 
 // Infof logs an info message with formatting.
 func Infof(format string, args ...interface{}) {
+	if defaultLogger.skipFormat(InfoLevel) {
+		return
+	}
 	defaultLogger.log(InfoLevel, fmt.Sprintf(format, args...))
 }
 
 // Warnf logs a warning message with formatting.
 func Warnf(format string, args ...interface{}) {
+	if defaultLogger.skipFormat(WarnLevel) {
+		return
+	}
 	defaultLogger.log(WarnLevel, fmt.Sprintf(format, args...))
 }
 
 // Errorf logs an error message with formatting.
 func Errorf(format string, args ...interface{}) {
+	if defaultLogger.skipFormat(ErrorLevel) {
+		return
+	}
 	defaultLogger.log(ErrorLevel, fmt.Sprintf(format, args...))
 }
 
 // Fatalf logs a fatal message with formatting and exit.
 func Fatalf(format string, args ...interface{}) {
-	defaultLogger.log(FatalLevel, fmt.Sprintf(format, args...))
-	os.Exit(1)
+	msg := fmt.Sprintf(format, args...)
+	defaultLogger.log(FatalLevel, msg)
+	defaultLogger.exit(msg)
 }
 
 // Printf logs a message with formatting and no level.
@@ -203,6 +332,42 @@ func Printf(format string, args ...interface{}) {
 	defaultLogger.log(noLevel, fmt.Sprintf(format, args...))
 }
 
+// Debugln logs a debug message made of args separated by spaces, in the
+// manner of fmt.Println.
+func Debugln(args ...interface{}) {
+	if defaultLogger.skipFormat(DebugLevel) {
+		return
+	}
+	defaultLogger.log(DebugLevel, sprintln(args...))
+}
+
+// Infoln logs an info message made of args separated by spaces, in the
+// manner of fmt.Println.
+func Infoln(args ...interface{}) {
+	if defaultLogger.skipFormat(InfoLevel) {
+		return
+	}
+	defaultLogger.log(InfoLevel, sprintln(args...))
+}
+
+// Warnln logs a warning message made of args separated by spaces, in the
+// manner of fmt.Println.
+func Warnln(args ...interface{}) {
+	if defaultLogger.skipFormat(WarnLevel) {
+		return
+	}
+	defaultLogger.log(WarnLevel, sprintln(args...))
+}
+
+// Errorln logs an error message made of args separated by spaces, in the
+// manner of fmt.Println.
+func Errorln(args ...interface{}) {
+	if defaultLogger.skipFormat(ErrorLevel) {
+		return
+	}
+	defaultLogger.log(ErrorLevel, sprintln(args...))
+}
+
 // StandardLog returns a standard logger from the default logger.
 func StandardLog(opts ...StandardLogOptions) *log.Logger {
 	return defaultLogger.StandardLog(opts...)
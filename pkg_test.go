@@ -128,6 +128,19 @@ func TestDebugf(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("0001/01/01 00:00:00 DEBU <log/%s:%d> debug foo\n", filepath.Base(file), line+1), buf.String())
 }
 
+func TestInfoln(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetLevel(InfoLevel)
+	SetReportTimestamp(false)
+	SetReportCaller(false)
+	Debugln("filtered")
+	Infoln("info", 1)
+	Warnln("warn", 2)
+	Errorln("error", 3)
+	assert.Equal(t, "INFO info 1\nWARN warn 2\nERRO error 3\n", buf.String())
+}
+
 func TestInfof(t *testing.T) {
 	var buf bytes.Buffer
 	SetOutput(&buf)
@@ -208,3 +221,28 @@ func TestWithPrefix(t *testing.T) {
 	l := WithPrefix("test")
 	assert.Equal(t, "test", l.prefix)
 }
+
+func TestLogCaller(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetLevel(DebugLevel)
+	SetReportTimestamp(false)
+	SetReportCaller(true)
+	SetFormatter(TextFormatter)
+	_, file, line, _ := runtime.Caller(0)
+	Log(WarnLevel, "warn")
+	assert.Equal(t, fmt.Sprintf("WARN <log/%s:%d> warn\n", filepath.Base(file), line+1), buf.String())
+}
+
+func TestLogIfCaller(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetLevel(DebugLevel)
+	SetReportTimestamp(false)
+	SetReportCaller(true)
+	SetFormatter(TextFormatter)
+	InfoIf(false, "skipped")
+	_, file, line, _ := runtime.Caller(0)
+	InfoIf(true, "info")
+	assert.Equal(t, fmt.Sprintf("INFO <log/%s:%d> info\n", filepath.Base(file), line+1), buf.String())
+}
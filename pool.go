@@ -0,0 +1,96 @@
+package log
+
+import "sync/atomic"
+
+// Pool runs tasks on a pool of goroutines, like *ants.Pool from
+// github.com/panjf2000/ants.
+type Pool interface {
+	Submit(task func()) error
+}
+
+// WithGoroutinePool returns a logger option that formats and writes entries
+// asynchronously, by submitting them as tasks to pool, instead of blocking
+// the logging call. This bounds the number of goroutines used during log
+// bursts. Entries are still created synchronously, so the timestamp, caller,
+// and fields reflect the logging call, but values are formatted later and
+// entries may be written out of order.
+//
+// Fatal entries are always written synchronously, and an entry is written
+// synchronously if the pool rejects it, like when it's overloaded or closed.
+// Other background tasks of the logger, like latency budget warnings, also
+// run on the pool.
+func WithGoroutinePool(pool Pool) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.pool = pool
+	}
+}
+
+// async runs fn on the pool of the logger, or on a new goroutine if there's
+// none or it rejects fn.
+func (l *Logger) async(fn func()) {
+	if l.pool != nil && l.pool.Submit(fn) == nil {
+		return
+	}
+	go fn()
+}
+
+// submit creates the entries of a logging call, and submits a task writing
+// them to the pool of the logger. They're written synchronously if the pool
+// rejects the task. release, if not nil, is called once the entries are
+// written.
+func (l *Logger) submit(depth int, progress bool, dropped int, level Level, msg interface{}, keyvals []interface{}, release func()) {
+	// Skip submit itself.
+	entries := l.entries(depth+1, progress, dropped, level, msg, keyvals)
+	if len(entries) == 0 {
+		if release != nil {
+			release()
+		}
+		return
+	}
+
+	task := func() {
+		if release != nil {
+			defer release()
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.recoverPanics {
+			defer l.recoverPanic()
+		}
+		for _, e := range entries {
+			l.write(e)
+		}
+	}
+	if err := l.pool.Submit(task); err != nil {
+		task()
+	}
+}
+
+// entries creates the entries of a logging call, including the rate limiting
+// warning if entries were dropped.
+func (l *Logger) entries(depth int, progress bool, dropped int, level Level, msg interface{}, keyvals []interface{}) []*LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.recoverPanics {
+		defer l.recoverPanic()
+	}
+
+	var entries []*LogEntry
+	if dropped > 0 {
+		if e := l.entry(depth+1, WarnLevel, "log rate limited", RateLimitedKey, dropped); e != nil {
+			entries = append(entries, e)
+		}
+	}
+	if e := l.entry(depth+1, level, msg, keyvals...); e != nil {
+		e.progress = progress
+		entries = append(entries, e)
+		if l.reportDelta {
+			atomic.StoreInt64(&l.lastLog, e.now.UnixNano())
+		}
+	} else if l.once != nil {
+		l.forget(level, msg)
+	}
+	return entries
+}
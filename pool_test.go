@@ -0,0 +1,82 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakePool is a pool that queues tasks until they're run.
+type fakePool struct {
+	tasks []func()
+	err   error
+}
+
+func (p *fakePool) Submit(task func()) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.tasks = append(p.tasks, task)
+	return nil
+}
+
+func (p *fakePool) run() {
+	tasks := p.tasks
+	p.tasks = nil
+	for _, task := range tasks {
+		task()
+	}
+}
+
+func TestWithGoroutinePool(t *testing.T) {
+	var buf bytes.Buffer
+	pool := &fakePool{}
+	l := New(&buf, WithGoroutinePool(pool))
+	l.SetReportCaller(true)
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("hello", "foo", "bar")
+	l.With("a", 1).Warn("pooled")
+	require.Empty(t, buf.String())
+	require.Len(t, pool.tasks, 2)
+
+	pool.run()
+	expected := fmt.Sprintf("INFO <log/pool_test.go:%d> hello foo=bar\nWARN <log/pool_test.go:%d> pooled a=1\n", line+1, line+2)
+	require.Equal(t, expected, buf.String())
+}
+
+func TestWithGoroutinePool_rejected(t *testing.T) {
+	var buf bytes.Buffer
+	pool := &fakePool{err: errors.New("pool overloaded")}
+	l := New(&buf, WithGoroutinePool(pool))
+	l.Info("hello")
+	require.Equal(t, "INFO hello\n", buf.String())
+}
+
+func TestWithGoroutinePool_fatal(t *testing.T) {
+	var buf bytes.Buffer
+	pool := &fakePool{}
+	l := New(&buf, WithGoroutinePool(pool), WithPanicOnCritical(true))
+	require.Panics(t, func() { l.Fatal("crashed") })
+	require.Equal(t, "FATA crashed\n", buf.String())
+	require.Empty(t, pool.tasks)
+}
+
+func TestWithGoroutinePool_dropped(t *testing.T) {
+	var buf bytes.Buffer
+	pool := &fakePool{}
+	l := New(&buf, WithGoroutinePool(pool))
+	l.Debug("filtered")
+	require.Empty(t, pool.tasks)
+
+	l = l.Intercept(func(level Level, msg string, keyvals []interface{}) (Level, string, []interface{}) {
+		return DropLevel, msg, keyvals
+	})
+	l.Info("dropped")
+	require.Empty(t, pool.tasks)
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,63 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+)
+
+var (
+	// HostnameKey is the key of the hostname field.
+	HostnameKey = "host"
+	// ProcessNameKey is the key of the process name field.
+	ProcessNameKey = "proc"
+	// PIDKey is the key of the process ID field.
+	PIDKey = "pid"
+)
+
+// addFields appends keyvals to the logger fields. It copies the fields so
+// that they're never shared with other loggers.
+func (l *Logger) addFields(keyvals ...interface{}) {
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals))
+	fields = append(fields, l.fields...)
+	l.fields = append(fields, keyvals...)
+}
+
+// WithHostname returns a logger option that appends a "host" field to every
+// entry with the hostname of the machine. The hostname is resolved once, when
+// the option is applied.
+func WithHostname() LoggerOption {
+	return func(l *Logger) {
+		host, err := os.Hostname()
+		if err != nil {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.addFields(HostnameKey, host)
+	}
+}
+
+// WithProcessName returns a logger option that appends a "proc" field to
+// every entry with the base name of the executable. The name is resolved
+// once, when the option is applied.
+func WithProcessName() LoggerOption {
+	return func(l *Logger) {
+		exe, err := os.Executable()
+		if err != nil {
+			return
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.addFields(ProcessNameKey, filepath.Base(exe))
+	}
+}
+
+// WithPID returns a logger option that appends a "pid" field to every entry
+// with the process ID.
+func WithPID() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.addFields(PIDKey, os.Getpid())
+	}
+}
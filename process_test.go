@@ -0,0 +1,31 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostname(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{Fields: []interface{}{"foo", "bar"}}, WithHostname())
+	l.SetFormatter(JSONFormatter)
+	l.Info("info")
+	require.Equal(t, []interface{}{"foo", "bar", HostnameKey, host}, l.fields)
+	require.Contains(t, buf.String(), `"host":"`+host+`"`)
+}
+
+func TestProcessNameAndPID(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	l := New(&buf, WithProcessName(), WithPID())
+	l.Info("info")
+	require.Equal(t, fmt.Sprintf("INFO info proc=%s pid=%d\n", filepath.Base(exe), os.Getpid()), buf.String())
+}
@@ -0,0 +1,55 @@
+package log
+
+import (
+	"bytes"
+
+	"github.com/muesli/termenv"
+)
+
+// clearLine clears the current terminal line and moves the cursor to its
+// beginning.
+const clearLine = "\x1b[2K\r"
+
+// Progress logs a progress entry at info level, overwriting the previous
+// progress entry on the same terminal line, instead of appending a new line.
+// It's meant for command line tools reporting the progress of long tasks:
+//
+//	for i, f := range files {
+//		logger.Progress("copying", "file", f, "done", i, "total", len(files))
+//	}
+//	logger.Finalize()
+//
+// The next entry that isn't a progress entry is written on a new line,
+// leaving the last progress entry visible. When the output isn't a terminal,
+// or doesn't support colors, Progress is the same as Info.
+func (l *Logger) Progress(msg interface{}, keyvals ...interface{}) {
+	l.mu.RLock()
+	tty := l.re.ColorProfile() != termenv.Ascii
+	l.mu.RUnlock()
+	// Call stack is log.Progress -> log.emit (1)
+	l.emit(1, tty, InfoLevel, msg, keyvals)
+}
+
+// Finalize ends the last progress entry, if any, with a newline, so that the
+// terminal prompt or the next output doesn't overwrite it. Call it once done
+// reporting progress.
+func (l *Logger) Finalize() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.progressLine {
+		l.progressLine = false
+		_, _ = l.w.Write([]byte("\n"))
+	}
+}
+
+// progressBytes returns the formatted entry p, as a progress line if e is a
+// progress entry, or on a new line if it follows a progress line. It must be
+// called with the lock held.
+func (l *Logger) progressBytes(e *LogEntry, p []byte) []byte {
+	if e.progress {
+		l.progressLine = true
+		return append([]byte(clearLine), bytes.TrimSuffix(p, []byte("\n"))...)
+	}
+	l.progressLine = false
+	return append([]byte("\n"), p...)
+}
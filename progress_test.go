@@ -0,0 +1,80 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+// sgrRe matches the escape sequences setting colors and styles.
+var sgrRe = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// ttyRenderer returns a renderer of w that renders colors, like for a
+// terminal.
+func ttyRenderer(w io.Writer) *lipgloss.Renderer {
+	re := lipgloss.NewRenderer(w)
+	re.SetColorProfile(termenv.ANSI)
+	return re
+}
+
+func stripColors(s string) string {
+	return sgrRe.ReplaceAllString(s, "")
+}
+
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.re = ttyRenderer(&buf)
+
+	l.Progress("copying", "done", 1)
+	l.Progress("copying", "done", 2)
+	require.Equal(t, clearLine+"INFO copying done=1"+clearLine+"INFO copying done=2", stripColors(buf.String()))
+
+	l.Warn("slow disk")
+	l.Progress("copying", "done", 3)
+	l.Finalize()
+	l.Finalize()
+	require.Equal(t, clearLine+"INFO copying done=1"+clearLine+"INFO copying done=2\n"+
+		"WARN slow disk\n"+clearLine+"INFO copying done=3\n", stripColors(buf.String()))
+}
+
+func TestProgress_notTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.Progress("copying", "done", 1)
+	l.Progress("copying", "done", 2)
+	l.Finalize()
+	require.Equal(t, "INFO copying done=1\nINFO copying done=2\n", buf.String())
+}
+
+func TestProgress_filtered(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.re = ttyRenderer(&buf)
+	l.SetLevel(WarnLevel)
+	l.Progress("copying")
+	l.Finalize()
+	require.Empty(t, buf.String())
+}
+
+func TestProgress_exported(t *testing.T) {
+	var buf, dst bytes.Buffer
+	l := New(&buf)
+	l.re = ttyRenderer(&buf)
+	l.SetReportCaller(true)
+	d := New(&dst)
+	l.ExportTo(d)
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Progress("copying", "done", 1)
+	caller := fmt.Sprintf("<log/progress_test.go:%d>", line+1)
+	require.Equal(t, clearLine+"INFO "+caller+" copying done=1", stripColors(buf.String()))
+	require.Equal(t, "INFO copying done=1\n", dst.String())
+}
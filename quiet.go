@@ -0,0 +1,48 @@
+package log
+
+import "time"
+
+// quietHours is a daily time window during which the logger level is raised.
+type quietHours struct {
+	start, end time.Duration
+	level      Level
+}
+
+// WithQuietHours returns a logger option that raises the level of the logger
+// to quietLevel during the daily window [start, end), where start and end are
+// offsets from midnight, in the location of the logger time function. The
+// window wraps around midnight if end is before start:
+//
+//	// Only log errors between 10pm and 6am.
+//	log.WithQuietHours(22*time.Hour, 6*time.Hour, log.ErrorLevel)
+//
+// The level is only ever raised: during the window, an entry must pass both
+// the logger level and quietLevel. GetLevel still returns the logger level.
+func WithQuietHours(start, end time.Duration, quietLevel Level) LoggerOption {
+	return func(l *Logger) {
+		l.quietHours.Store(&quietHours{start: start, end: end, level: quietLevel})
+	}
+}
+
+// quiet returns true if entries at level are silenced by the quiet hours of
+// the logger.
+func (l *Logger) quiet(level Level) bool {
+	q, _ := l.quietHours.Load().(*quietHours)
+	if q == nil || level >= q.level {
+		return false
+	}
+	l.mu.RLock()
+	now := l.timeFunc()
+	l.mu.RUnlock()
+	return q.contains(now)
+}
+
+// contains returns true if t is within the window.
+func (q *quietHours) contains(t time.Time) bool {
+	y, m, d := t.Date()
+	since := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
+	if q.start <= q.end {
+		return since >= q.start && since < q.end
+	}
+	return since >= q.start || since < q.end
+}
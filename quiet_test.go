@@ -0,0 +1,105 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQuietHours(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2023, 4, 5, hour, min, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		name       string
+		start, end time.Duration
+		now        time.Time
+		expected   string
+	}{
+		{
+			name:     "before window",
+			start:    2 * time.Hour,
+			end:      4 * time.Hour,
+			now:      at(1, 59),
+			expected: "INFO info\nERRO error\n",
+		},
+		{
+			name:     "window start",
+			start:    2 * time.Hour,
+			end:      4 * time.Hour,
+			now:      at(2, 0),
+			expected: "ERRO error\n",
+		},
+		{
+			name:     "window end",
+			start:    2 * time.Hour,
+			end:      4 * time.Hour,
+			now:      at(4, 0),
+			expected: "INFO info\nERRO error\n",
+		},
+		{
+			name:     "wrapped before midnight",
+			start:    22 * time.Hour,
+			end:      6 * time.Hour,
+			now:      at(23, 30),
+			expected: "ERRO error\n",
+		},
+		{
+			name:     "wrapped after midnight",
+			start:    22 * time.Hour,
+			end:      6 * time.Hour,
+			now:      at(5, 0),
+			expected: "ERRO error\n",
+		},
+		{
+			name:     "wrapped outside",
+			start:    22 * time.Hour,
+			end:      6 * time.Hour,
+			now:      at(12, 0),
+			expected: "INFO info\nERRO error\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, WithQuietHours(c.start, c.end, ErrorLevel))
+			l.SetTimeFunction(func() time.Time { return c.now })
+			l.Debug("debug")
+			l.Info("info")
+			l.Error("error")
+			require.Equal(t, c.expected, buf.String())
+			require.Equal(t, InfoLevel, l.GetLevel())
+		})
+	}
+}
+
+func TestWithQuietHours_location(t *testing.T) {
+	var buf bytes.Buffer
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	l := New(&buf, WithQuietHours(2*time.Hour, 4*time.Hour, ErrorLevel))
+	// 00:30 UTC is 03:30 in loc.
+	l.SetTimeFunction(func() time.Time { return time.Date(2023, 4, 5, 0, 30, 0, 0, time.UTC).In(loc) })
+	l.Info("info")
+	require.Empty(t, buf.String())
+}
+
+func TestWithQuietHours_live(t *testing.T) {
+	l := New(&syncBuffer{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			l.Info("info")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		WithQuietHours(0, 24*time.Hour, ErrorLevel)(l)
+	}
+	<-done
+	var buf bytes.Buffer
+	l.SetOutput(&buf)
+	l.Info("quiet")
+	require.Empty(t, buf.String())
+}
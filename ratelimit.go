@@ -0,0 +1,55 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimitedKey is the key of the field reporting how many entries were
+// dropped by the rate limiter.
+var RateLimitedKey = "log_rate_limited"
+
+// rateLimiter limits the number of entries per period.
+type rateLimiter struct {
+	n   int
+	per time.Duration
+
+	mu      sync.Mutex
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// allow returns whether an entry logged at now is allowed. When a new period
+// starts, it also returns the number of entries dropped during the previous
+// periods.
+func (r *rateLimiter) allow(now time.Time) (ok bool, dropped int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.start) >= r.per {
+		r.start = now
+		r.count = 0
+		dropped, r.dropped = r.dropped, 0
+	}
+	if r.count >= r.n {
+		r.dropped++
+		return false, 0
+	}
+	r.count++
+	return true, dropped
+}
+
+// WithMaxLogRate returns a logger option that limits the logger to at most n
+// entries per period, across all levels. Entries beyond the rate are dropped.
+// After a period where entries were dropped, the next entry is preceded by a
+// warning with a "log_rate_limited" field reporting the number of dropped
+// entries.
+//
+// Loggers derived from the logger share the same limit.
+func WithMaxLogRate(n int, per time.Duration) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.rateLimiter = &rateLimiter{n: n, per: per}
+	}
+}
@@ -0,0 +1,44 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxLogRate(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithMaxLogRate(2, 50*time.Millisecond))
+	l.Info("1")
+	l.Warn("2")
+	l.Error("3")
+	l.With("foo", "bar").Info("4")
+	require.Equal(t, "INFO 1\nWARN 2\n", buf.String())
+
+	buf.Reset()
+	time.Sleep(60 * time.Millisecond)
+	l.Info("5")
+	require.Equal(t, "WARN log rate limited log_rate_limited=2\nINFO 5\n", buf.String())
+
+	buf.Reset()
+	time.Sleep(60 * time.Millisecond)
+	l.Info("6")
+	require.Equal(t, "INFO 6\n", buf.String())
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	r := &rateLimiter{n: 1, per: time.Second}
+	ok, dropped := r.allow(now)
+	require.True(t, ok)
+	require.Zero(t, dropped)
+	for i := 0; i < 3; i++ {
+		ok, _ = r.allow(now.Add(time.Millisecond))
+		require.False(t, ok)
+	}
+	ok, dropped = r.allow(now.Add(time.Second))
+	require.True(t, ok)
+	require.Equal(t, 3, dropped)
+}
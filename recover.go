@@ -0,0 +1,130 @@
+package log
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+var (
+	// PanicKey is the key of the field holding a recovered panic value.
+	PanicKey = "panic"
+
+	// StackKey is the key of the field holding a stack trace.
+	StackKey = "stack"
+)
+
+// Recover returns a function that recovers from a panic, logs the panic value
+// and stack trace at error level, and panics again with the same value. It's
+// meant to be deferred:
+//
+//	defer log.Recover(logger)()
+func Recover(l *Logger) func() {
+	return func() {
+		if r := recover(); r != nil {
+			logPanic(l, r, "panic", true)
+			panic(r)
+		}
+	}
+}
+
+// RecoverAndContinue calls fn, and recovers from any panic it raises. The
+// panic value and stack trace are logged at error level, and execution
+// continues normally.
+func RecoverAndContinue(l *Logger, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			logPanic(l, r, "panic", true)
+		}
+	}()
+	fn()
+}
+
+// Recover recovers from a panic, and logs the panic value at error level with
+// msg and keyvals, along with the stack trace if the logger was created with
+// WithStackTraces. It does nothing if there's no panic. Since recover only
+// works when called directly by a deferred function, Recover itself must be
+// deferred:
+//
+//	defer logger.Recover("caught panic", "job", id)
+func (l *Logger) Recover(msg string, keyvals ...interface{}) {
+	if r := recover(); r != nil {
+		logPanic(l, r, msg, l.stackTraces, keyvals...)
+	}
+}
+
+// WithStackTraces returns a logger option that includes the stack trace in
+// the panics logged by Logger.Recover.
+func WithStackTraces() LoggerOption {
+	return func(l *Logger) {
+		l.stackTraces = true
+	}
+}
+
+// logPanic logs a recovered panic value with msg and keyvals, and the stack
+// trace if stack is true. It must be called directly from the deferred
+// function that recovered the panic, so that the reported caller is the place
+// where the panic happened.
+func logPanic(l *Logger, r interface{}, msg string, stack bool, keyvals ...interface{}) {
+	kvs := append(keyvals[:len(keyvals):len(keyvals)], PanicKey, r)
+	if stack {
+		kvs = append(kvs, StackKey, string(debug.Stack()))
+	}
+	// Call stack is logPanic -> deferred function -> runtime frames (2+n)
+	l.logDepth(2+panicDepth(), ErrorLevel, msg, kvs...)
+}
+
+// panicDepth returns the number of runtime frames between the deferred
+// function that called logPanic and the function that panicked.
+func panicDepth() int {
+	var pcs [16]uintptr
+	// Skip runtime.Callers, panicDepth, logPanic and the deferred function.
+	n := runtime.Callers(4, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	depth := 0
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			break
+		}
+		depth++
+		if !more {
+			break
+		}
+	}
+	return depth
+}
+
+// WithPanicRecovery returns a logger option that recovers from panics raised
+// while handling an entry, like in a pipeline transformer or while
+// formatting. The panic value is logged at level using the text formatter,
+// instead of crashing the program.
+func WithPanicRecovery(level Level) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.recoverPanics = true
+		l.panicLevel = level
+	}
+}
+
+// recoverPanic recovers from a panic raised while handling an entry, and logs
+// the panic value with the text formatter. It must be deferred while holding
+// the logger lock.
+func (l *Logger) recoverPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	l.b.Reset()
+	defer l.b.Reset()
+
+	var kvs []interface{}
+	if l.reportTimestamp {
+		kvs = append(kvs, TimestampKey, l.timeFunc())
+	}
+	kvs = append(kvs, LevelKey, l.panicLevel, MessageKey, "panic while logging", PanicKey, r)
+	l.textFormatter(&l.b, kvs...)
+	_, _ = l.w.Write(l.b.Bytes())
+}
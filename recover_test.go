@@ -0,0 +1,103 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		if e.Message == "boom" {
+			panic("transformer panic")
+		}
+		return e
+	}))
+	l := New(&buf, WithPipeline(p), WithPanicRecovery(ErrorLevel))
+	l.Info("boom", "foo", "bar")
+	require.Equal(t, "ERRO panic while logging panic=\"transformer panic\"\n", buf.String())
+	buf.Reset()
+	l.Info("info")
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+func TestPanicRecovery_disabled(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		panic("transformer panic")
+	}))
+	l := New(&buf, WithPipeline(p))
+	require.Panics(t, func() {
+		l.Info("boom")
+	})
+}
+
+func TestRecover(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{Formatter: JSONFormatter, ReportCaller: true})
+	require.PanicsWithValue(t, "boom", func() {
+		defer Recover(l)()
+		panic("boom")
+	})
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, "error", m[LevelKey])
+	require.Equal(t, "panic", m[MessageKey])
+	require.Equal(t, "boom", m[PanicKey])
+	require.Contains(t, m[StackKey], "TestRecover")
+	require.Contains(t, m[CallerKey], "recover_test.go:")
+}
+
+func TestRecoverAndContinue(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{Formatter: JSONFormatter})
+	require.NotPanics(t, func() {
+		RecoverAndContinue(l, func() {
+			var m map[string]int
+			m["foo"] = 1
+		})
+	})
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, "error", m[LevelKey])
+	require.Contains(t, m[PanicKey], "nil map")
+	require.Contains(t, m[StackKey], "TestRecoverAndContinue")
+
+	buf.Reset()
+	RecoverAndContinue(l, func() {})
+	require.Empty(t, buf.String())
+}
+
+func TestLogger_Recover(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithOptions(&buf, Options{ReportCaller: true})
+	require.NotPanics(t, func() {
+		defer l.Recover("caught panic", "job", 42)
+		panic("boom")
+	})
+	require.Equal(t, "ERRO <log/recover_test.go:79> caught panic job=42 panic=boom\n", buf.String())
+
+	buf.Reset()
+	func() {
+		defer l.Recover("caught panic")
+	}()
+	require.Empty(t, buf.String())
+}
+
+func TestLogger_Recover_stackTraces(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithStackTraces())
+	l.SetFormatter(JSONFormatter)
+	func() {
+		defer l.Recover("caught panic")
+		panic("boom")
+	}()
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, "caught panic", m[MessageKey])
+	require.Equal(t, "boom", m[PanicKey])
+	require.Contains(t, m[StackKey], "TestLogger_Recover_stackTraces")
+}
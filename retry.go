@@ -0,0 +1,146 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrBackoff is returned by writes that are discarded while backing off after
+// a write failure.
+var ErrBackoff = errors.New("backing off after write failure")
+
+// retryWriter is a writer that retries failed writes.
+type retryWriter struct {
+	w           io.Writer
+	maxAttempts int
+	delay       time.Duration
+}
+
+// Write implements io.Writer.
+func (r *retryWriter) Write(p []byte) (int, error) {
+	return r.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p, an entry at level, to the underlying writer, retrying
+// on failure. After a partial write, only the rest of p is retried.
+func (r *retryWriter) WriteLevel(level Level, p []byte) (n int, err error) {
+	for i := 0; i < r.maxAttempts && n < len(p); i++ {
+		if i > 0 {
+			time.Sleep(r.delay)
+		}
+		var m int
+		m, err = writeLevel(r.w, level, p[n:])
+		if m > 0 && m <= len(p)-n {
+			n += m
+		}
+		if err == nil {
+			return n, nil
+		}
+	}
+	return n, err
+}
+
+// Unwrap returns the underlying writer.
+func (r *retryWriter) Unwrap() io.Writer {
+	return r.w
+}
+
+// WithRetryWriter returns a logger option that retries failed writes to the
+// current output up to maxAttempts times, waiting delay between attempts.
+// When all attempts fail, the entry is written to the fallback writer, if
+// any, or dropped.
+func WithRetryWriter(maxAttempts int, delay time.Duration) LoggerOption {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.w = &retryWriter{
+			w:           l.w,
+			maxAttempts: maxAttempts,
+			delay:       delay,
+		}
+	}
+}
+
+// backoffWriter is a writer that backs off exponentially after write
+// failures.
+type backoffWriter struct {
+	w    io.Writer
+	base time.Duration
+	max  time.Duration
+
+	mu      sync.Mutex
+	backoff time.Duration
+	next    time.Time
+}
+
+// Write implements io.Writer.
+func (b *backoffWriter) Write(p []byte) (int, error) {
+	return b.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p, an entry at level, to the underlying writer unless it's
+// backing off.
+func (b *backoffWriter) WriteLevel(level Level, p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(b.next) {
+		return 0, ErrBackoff
+	}
+
+	n, err := writeLevel(b.w, level, p)
+	if err != nil {
+		if b.backoff == 0 {
+			b.backoff = b.base
+		}
+		b.next = now.Add(b.backoff)
+		if b.backoff *= 2; b.backoff > b.max {
+			b.backoff = b.max
+		}
+		return n, err
+	}
+
+	b.backoff = 0
+	b.next = time.Time{}
+	return n, nil
+}
+
+// Unwrap returns the underlying writer.
+func (b *backoffWriter) Unwrap() io.Writer {
+	return b.w
+}
+
+// WithExponentialBackoffOnError returns a logger option that backs off after
+// a failed write to the current output. Entries are discarded for base after
+// the first failure, and the wait doubles on each consecutive failure up to
+// max. The wait is reset to base after a successful write.
+func WithExponentialBackoffOnError(base, max time.Duration) LoggerOption {
+	if max < base {
+		max = base
+	}
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.w = &backoffWriter{
+			w:    l.w,
+			base: base,
+			max:  max,
+		}
+	}
+}
+
+// WithFallbackWriter returns a logger option that writes entries to w when
+// writing to the output fails.
+func WithFallbackWriter(w io.Writer) LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.fallback = w
+	}
+}
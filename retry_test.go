@@ -0,0 +1,113 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// flakyWriter is a writer that fails the first n writes.
+type flakyWriter struct {
+	bytes.Buffer
+	n      int
+	writes int
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes <= w.n {
+		return 0, errWrite
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestRetryWriter(t *testing.T) {
+	w := &flakyWriter{n: 2}
+	l := New(w, WithRetryWriter(3, 0))
+	l.Info("info")
+	require.Equal(t, 3, w.writes)
+	require.Equal(t, "INFO info\n", w.String())
+}
+
+func TestRetryWriter_fallback(t *testing.T) {
+	var fallback bytes.Buffer
+	w := &flakyWriter{n: 3}
+	l := New(w, WithRetryWriter(3, 0), WithFallbackWriter(&fallback))
+	l.Info("info")
+	require.Equal(t, 3, w.writes)
+	require.Equal(t, "", w.String())
+	require.Equal(t, "INFO info\n", fallback.String())
+}
+
+// shortWriter is a writer that fails after writing at most n bytes at a time.
+type shortWriter struct {
+	bytes.Buffer
+	n      int
+	writes int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if len(p) > w.n {
+		n, _ := w.Buffer.Write(p[:w.n])
+		return n, errWrite
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestRetryWriter_partial(t *testing.T) {
+	w := &shortWriter{n: 4}
+	l := New(w, WithRetryWriter(3, 0))
+	l.Info("info")
+	require.Equal(t, 3, w.writes)
+	require.Equal(t, "INFO info\n", w.String())
+}
+
+func TestRetryWriter_drop(t *testing.T) {
+	w := &flakyWriter{n: 2}
+	l := New(w, WithRetryWriter(2, 0))
+	l.Info("dropped")
+	l.Info("info")
+	require.Equal(t, 3, w.writes)
+	require.Equal(t, "INFO info\n", w.String())
+}
+
+func TestBackoffWriter(t *testing.T) {
+	w := &failWriter{fail: true}
+	b := &backoffWriter{w: w, base: 20 * time.Millisecond, max: 50 * time.Millisecond}
+
+	_, err := b.Write([]byte("1"))
+	require.ErrorIs(t, err, errWrite)
+	_, err = b.Write([]byte("2"))
+	require.ErrorIs(t, err, ErrBackoff)
+	require.Equal(t, 1, w.writes)
+	require.Equal(t, 40*time.Millisecond, b.backoff)
+
+	time.Sleep(25 * time.Millisecond)
+	_, err = b.Write([]byte("3"))
+	require.ErrorIs(t, err, errWrite)
+	require.Equal(t, 2, w.writes)
+	require.Equal(t, 50*time.Millisecond, b.backoff)
+
+	w.fail = false
+	time.Sleep(25 * time.Millisecond)
+	_, err = b.Write([]byte("4"))
+	require.ErrorIs(t, err, ErrBackoff)
+	time.Sleep(20 * time.Millisecond)
+	_, err = b.Write([]byte("5"))
+	require.NoError(t, err)
+	require.Zero(t, b.backoff)
+	require.Equal(t, "5", w.String())
+}
+
+func TestExponentialBackoffOnError(t *testing.T) {
+	w := &failWriter{fail: true}
+	l := New(w, WithExponentialBackoffOnError(time.Hour, time.Hour))
+	l.Info("1")
+	w.fail = false
+	l.Info("2")
+	require.Equal(t, 1, w.writes)
+	require.Equal(t, "", w.String())
+}
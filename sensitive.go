@@ -0,0 +1,35 @@
+package log
+
+// WithSensitiveKey returns a new logger that marks keys as sensitive. This is
+// metadata only: sensitive fields are still logged as is, but the keys are
+// available to pipeline transformers and formatters in
+// LogEntry.SensitiveKeys, so that they can redact or route them.
+func (l *Logger) WithSensitiveKey(keys ...string) *Logger {
+	sl := l.With()
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	merged := make([]string, 0, len(l.sensitiveKeys)+len(keys))
+	merged = append(merged, l.sensitiveKeys...)
+	for _, k := range keys {
+		if !containsString(merged, k) {
+			merged = append(merged, k)
+		}
+	}
+	sl.sensitiveKeys = merged
+	return sl
+}
+
+// IsSensitive returns true if key is marked as sensitive for the entry.
+func (e *LogEntry) IsSensitive(key string) bool {
+	return containsString(e.SensitiveKeys, key)
+}
+
+// containsString returns true if s contains str.
+func containsString(s []string, str string) bool {
+	for _, v := range s {
+		if v == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSensitiveKey(t *testing.T) {
+	var buf bytes.Buffer
+	var seen [][]string
+	p := NewPipeline(TransformerFunc(func(e *LogEntry) *LogEntry {
+		seen = append(seen, e.SensitiveKeys)
+		for i := 0; i+1 < len(e.Fields); i += 2 {
+			if k, ok := e.Fields[i].(string); ok && e.IsSensitive(k) {
+				e.Fields[i+1] = "REDACTED"
+			}
+		}
+		return e
+	}))
+	l := New(&buf, WithPipeline(p))
+	sl := l.WithSensitiveKey("password").WithSensitiveKey("token", "password")
+
+	l.Info("plain", "password", "hunter2")
+	sl.Info("login", "user", "bob", "password", "hunter2", "token", "abc")
+	require.Equal(t, "INFO plain password=hunter2\nINFO login user=bob password=REDACTED token=REDACTED\n", buf.String())
+	require.Equal(t, [][]string{nil, {"password", "token"}}, seen)
+}
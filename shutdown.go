@@ -0,0 +1,89 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShutdownTimeout is returned when a logger shutdown times out waiting for
+// pending writes to complete.
+var ErrShutdownTimeout = errors.New("shutdown timed out")
+
+// shutdown tracks pending writes of a logger that can be shut down.
+type shutdown struct {
+	// mu is read-locked for every pending write and write-locked when
+	// shutting down.
+	mu     sync.RWMutex
+	closed uint32
+
+	start sync.Once
+	// done is closed once the pending writes have completed.
+	done chan struct{}
+
+	closeOnce sync.Once
+	err       error
+}
+
+// acquire registers a pending write. It returns false if the logger has been
+// shut down.
+func (s *shutdown) acquire() bool {
+	if atomic.LoadUint32(&s.closed) != 0 {
+		return false
+	}
+	s.mu.RLock()
+	if atomic.LoadUint32(&s.closed) != 0 {
+		s.mu.RUnlock()
+		return false
+	}
+	return true
+}
+
+// release marks a pending write as completed.
+func (s *shutdown) release() {
+	s.mu.RUnlock()
+}
+
+// WithGracefulShutdown returns a new logger and a function to shut it down.
+//
+// The shutdown function waits up to timeout, or until ctx is done, for all
+// pending writes to complete. It then flushes and closes the output. If the
+// wait times out, ErrShutdownTimeout is returned and the output is left
+// open; calling the shutdown function again waits again. Once the output is
+// closed, later calls return the error of closing it. Once shutdown is
+// called, logging with the returned logger, or any logger derived from it,
+// is a no-op.
+func (l *Logger) WithGracefulShutdown(timeout time.Duration) (*Logger, func(context.Context) error) {
+	s := &shutdown{done: make(chan struct{})}
+	sl := l.With()
+	sl.shutdown = s
+	return sl, func(ctx context.Context) error {
+		s.start.Do(func() {
+			atomic.StoreUint32(&s.closed, 1)
+			go func() {
+				// Once the lock is acquired, the pending writes have
+				// completed, and new ones see that the logger is closed.
+				s.mu.Lock()
+				close(s.done)
+				s.mu.Unlock()
+			}()
+		})
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return ErrShutdownTimeout
+		}
+		s.closeOnce.Do(func() {
+			s.err = sl.Close()
+		})
+		return s.err
+	}
+}
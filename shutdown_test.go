@@ -0,0 +1,95 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type closeBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closeBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	var buf closeBuffer
+	bw := bufio.NewWriter(&buf)
+	l, shutdown := New(bw).WithGracefulShutdown(time.Second)
+	l.Info("before")
+	require.Equal(t, "", buf.String())
+	require.NoError(t, shutdown(context.Background()))
+	require.Equal(t, "INFO before\n", buf.String())
+	l.Info("after")
+	l.With("foo", "bar").Info("after")
+	require.NoError(t, bw.Flush())
+	require.Equal(t, "INFO before\n", buf.String())
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestGracefulShutdown_close(t *testing.T) {
+	var buf closeBuffer
+	l, shutdown := New(&buf).WithGracefulShutdown(time.Second)
+	l.Info("info")
+	require.NoError(t, shutdown(context.Background()))
+	require.True(t, buf.closed)
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+func TestGracefulShutdown_timeout(t *testing.T) {
+	var buf closeBuffer
+	l, shutdown := New(&buf).WithGracefulShutdown(10 * time.Millisecond)
+	// Simulate a pending write.
+	require.True(t, l.shutdown.acquire())
+	require.ErrorIs(t, shutdown(context.Background()), ErrShutdownTimeout)
+	require.False(t, buf.closed)
+	l.shutdown.release()
+	l.Info("info")
+	require.Equal(t, "", buf.String())
+}
+
+func TestGracefulShutdown_context(t *testing.T) {
+	var buf closeBuffer
+	l, shutdown := New(&buf).WithGracefulShutdown(time.Second)
+	require.True(t, l.shutdown.acquire())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, shutdown(ctx), context.Canceled)
+	l.shutdown.release()
+}
+
+func TestGracefulShutdown_retry(t *testing.T) {
+	var buf closeBuffer
+	l, shutdown := New(&buf).WithGracefulShutdown(10 * time.Millisecond)
+	require.True(t, l.shutdown.acquire())
+	require.ErrorIs(t, shutdown(context.Background()), ErrShutdownTimeout)
+	require.ErrorIs(t, shutdown(context.Background()), ErrShutdownTimeout)
+	require.False(t, buf.closed)
+
+	l.shutdown.release()
+	require.NoError(t, shutdown(context.Background()))
+	require.True(t, buf.closed)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+type errCloser struct {
+	bytes.Buffer
+}
+
+func (*errCloser) Close() error {
+	return errWrite
+}
+
+func TestGracefulShutdown_closeError(t *testing.T) {
+	_, shutdown := New(&errCloser{}).WithGracefulShutdown(time.Second)
+	require.ErrorIs(t, shutdown(context.Background()), errWrite)
+	require.ErrorIs(t, shutdown(context.Background()), errWrite)
+}
@@ -0,0 +1,88 @@
+package log
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// SignalFlush flushes a logger when the process receives a signal, see
+// WithSignalFlush.
+type SignalFlush struct {
+	c     chan os.Signal
+	done  chan struct{}
+	once  sync.Once
+	raise func(os.Signal)
+}
+
+// WithSignalFlush returns a logger option that catches signals, SIGINT and
+// SIGTERM by default, flushes the output of the logger, then raises the
+// signal again. This makes sure buffered entries aren't lost when a container
+// is stopped, for instance. Only the first signal is caught.
+//
+// The logger stops catching the signals before raising it again, so the
+// signal gets its previous behavior back: it terminates the process, unless
+// the application catches it too with signal.Notify. In that case, the
+// application receives it again and decides what to do.
+//
+// Use Logger.SignalFlush to stop catching the signals. Closing the logger
+// stops catching them too. Loggers derived with Logger.With don't share the
+// handler.
+func WithSignalFlush(signals ...os.Signal) LoggerOption {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.signalFlush != nil {
+			l.signalFlush.Cancel()
+		}
+		s := &SignalFlush{
+			c:     make(chan os.Signal, 1),
+			done:  make(chan struct{}),
+			raise: raiseSignal,
+		}
+		signal.Notify(s.c, signals...)
+		l.signalFlush = s
+		go s.run(l)
+	}
+}
+
+// SignalFlush returns the signal handler of the logger, set with
+// WithSignalFlush, or nil if there's none.
+func (l *Logger) SignalFlush() *SignalFlush {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.signalFlush
+}
+
+// Cancel stops catching the signals. The signals get their previous behavior
+// back.
+func (s *SignalFlush) Cancel() {
+	s.once.Do(func() {
+		signal.Stop(s.c)
+		close(s.done)
+	})
+}
+
+func (s *SignalFlush) run(l *Logger) {
+	select {
+	case sig := <-s.c:
+		_ = l.Flush()
+		s.Cancel()
+		s.raise(sig)
+	case <-s.done:
+	}
+}
+
+// raiseSignal sends sig to the current process. Once no channel is notified
+// of sig, the Go runtime restores its default behavior. It exits the process
+// if the signal can't be sent.
+func raiseSignal(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
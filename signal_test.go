@@ -0,0 +1,95 @@
+package log
+
+import (
+	"bufio"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSignalFlush(t *testing.T) {
+	var buf syncBuffer
+	l := New(bufio.NewWriter(&buf), WithSignalFlush())
+	s := l.SignalFlush()
+	require.NotNil(t, s)
+	raised := make(chan os.Signal, 1)
+	s.raise = func(sig os.Signal) { raised <- sig }
+
+	l.Info("buffered")
+	require.Empty(t, buf.String())
+	s.c <- syscall.SIGTERM
+	select {
+	case sig := <-raised:
+		require.Equal(t, syscall.SIGTERM, sig)
+	case <-time.After(time.Second):
+		t.Fatal("signal wasn't raised again")
+	}
+	require.Equal(t, "INFO buffered\n", buf.String())
+	<-s.done
+}
+
+func TestSignalFlush_appHandler(t *testing.T) {
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	app := make(chan os.Signal, 1)
+	signal.Notify(app, syscall.SIGINT)
+	defer signal.Stop(app)
+
+	var buf syncBuffer
+	l := New(bufio.NewWriter(&buf), WithSignalFlush(syscall.SIGINT))
+	s := l.SignalFlush()
+	l.Info("buffered")
+	if err := p.Signal(syscall.SIGINT); err != nil {
+		t.Skip("can't send signals:", err)
+	}
+	select {
+	case <-app:
+	case <-time.After(time.Second):
+		t.Fatal("application handler didn't receive the signal")
+	}
+	select {
+	case <-s.done:
+	case <-time.After(time.Second):
+		t.Fatal("signal wasn't caught")
+	}
+	require.Equal(t, "INFO buffered\n", buf.String())
+}
+
+func TestSignalFlush_Cancel(t *testing.T) {
+	l := New(nil, WithSignalFlush(syscall.SIGINT))
+	s := l.SignalFlush()
+	s.raise = func(os.Signal) { t.Error("signal raised after cancel") }
+	s.Cancel()
+	s.Cancel()
+	<-s.done
+
+	require.Nil(t, New(nil).SignalFlush())
+}
+
+func TestSignalFlush_with(t *testing.T) {
+	l := New(nil, WithSignalFlush())
+	defer l.SignalFlush().Cancel()
+	sub := l.With("key", "value")
+	require.Nil(t, sub.SignalFlush())
+	require.NoError(t, sub.Close())
+	select {
+	case <-l.SignalFlush().done:
+		t.Fatal("closing a derived logger canceled the signal flush")
+	default:
+	}
+}
+
+func TestSignalFlush_close(t *testing.T) {
+	var buf closeBuffer
+	l := New(&buf, WithSignalFlush())
+	require.NoError(t, l.Close())
+	select {
+	case <-l.SignalFlush().done:
+	default:
+		t.Fatal("signal flush wasn't canceled")
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package log
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalFlush_terminate(t *testing.T) {
+	if os.Getenv("SIGNAL_FLUSH") == "1" {
+		l := New(bufio.NewWriter(os.Stdout), WithSignalFlush(syscall.SIGTERM))
+		l.Info("buffered")
+		_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+		time.Sleep(5 * time.Second)
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=TestSignalFlush_terminate")
+	cmd.Env = append(os.Environ(), "SIGNAL_FLUSH=1")
+	out, err := cmd.Output()
+	require.Error(t, err)
+	e, ok := err.(*exec.ExitError)
+	require.True(t, ok, err)
+	status, ok := e.Sys().(syscall.WaitStatus)
+	require.True(t, ok)
+	require.True(t, status.Signaled(), "process exited with %v", err)
+	require.Equal(t, syscall.SIGTERM, status.Signal())
+	require.Equal(t, "INFO buffered\n", string(out))
+}
@@ -0,0 +1,133 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// SinkRouter is a Logger that writes each entry to all the registered sinks
+// whose minimum level the entry satisfies, instead of a single output. This
+// lets downstream systems with different verbosity needs, like a file and an
+// alerting service, share a logger. Sinks can be registered and deregistered
+// at runtime.
+//
+// Entries are filtered by the logger level first, so it should be at most the
+// lowest level of the sinks. Entries without a level, like the ones written
+// with Print, are written to all the sinks.
+type SinkRouter struct {
+	*Logger
+	w *sinkWriter
+}
+
+// NewSinkRouter returns a new SinkRouter with the options of l, and no sinks.
+// Loggers derived from it write to its sinks too.
+func NewSinkRouter(l *Logger) *SinkRouter {
+	sw := &sinkWriter{}
+	sl := l.With()
+	sl.w = sw
+	return &SinkRouter{Logger: sl, w: sw}
+}
+
+// RegisterSink registers w under name, to receive the entries at minLevel or
+// above. A sink already registered under name is replaced.
+func (r *SinkRouter) RegisterSink(name string, w io.Writer, minLevel Level) {
+	r.w.mu.Lock()
+	defer r.w.mu.Unlock()
+	sinks := make([]sink, 0, len(r.w.sinks)+1)
+	replaced := false
+	for _, s := range r.w.sinks {
+		if s.name == name {
+			s.w, s.level = w, minLevel
+			replaced = true
+		}
+		sinks = append(sinks, s)
+	}
+	if !replaced {
+		sinks = append(sinks, sink{name: name, w: w, level: minLevel})
+	}
+	r.w.sinks = sinks
+}
+
+// DeregisterSink removes the sink registered under name, if any.
+func (r *SinkRouter) DeregisterSink(name string) {
+	r.w.mu.Lock()
+	defer r.w.mu.Unlock()
+	sinks := make([]sink, 0, len(r.w.sinks))
+	for _, s := range r.w.sinks {
+		if s.name != name {
+			sinks = append(sinks, s)
+		}
+	}
+	r.w.sinks = sinks
+}
+
+// sink is a writer registered in a SinkRouter.
+type sink struct {
+	name  string
+	w     io.Writer
+	level Level
+}
+
+// sinkWriter writes entries to the sinks whose level they satisfy. The list of
+// sinks is replaced, never modified, so it can be written to without holding
+// the lock.
+type sinkWriter struct {
+	mu    sync.RWMutex
+	sinks []sink
+}
+
+// Write implements io.Writer. p is written to all the sinks.
+func (w *sinkWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p to the sinks whose level is satisfied by level. It
+// returns the first error, after writing to all the sinks.
+func (w *sinkWriter) WriteLevel(level Level, p []byte) (int, error) {
+	w.mu.RLock()
+	sinks := w.sinks
+	w.mu.RUnlock()
+
+	var err error
+	for _, s := range sinks {
+		if level < s.level {
+			continue
+		}
+		if _, werr := writeLevel(s.w, level, p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return len(p), err
+}
+
+// Flush flushes the sinks that buffer their output, and returns the first
+// error.
+func (w *sinkWriter) Flush() error {
+	w.mu.RLock()
+	sinks := w.sinks
+	w.mu.RUnlock()
+
+	var err error
+	for _, s := range sinks {
+		if ferr := flushWriter(s.w); ferr != nil && err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
+
+// Close closes the sinks that support closing, and returns the first error.
+// The standard output and error streams are never closed.
+func (w *sinkWriter) Close() error {
+	w.mu.RLock()
+	sinks := w.sinks
+	w.mu.RUnlock()
+
+	var err error
+	for _, s := range sinks {
+		if cerr := closeWriter(s.w); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
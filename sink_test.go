@@ -0,0 +1,95 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkRouter(t *testing.T) {
+	var file, alerts bytes.Buffer
+	r := NewSinkRouter(New(nil))
+	r.SetLevel(DebugLevel)
+	r.RegisterSink("file", &file, DebugLevel)
+	r.RegisterSink("alerts", &alerts, ErrorLevel)
+
+	r.Debug("debug")
+	r.With("foo", "bar").Error("error")
+	r.Print("print")
+	require.Equal(t, "DEBU debug\nERRO error foo=bar\nprint\n", file.String())
+	require.Equal(t, "ERRO error foo=bar\nprint\n", alerts.String())
+
+	file.Reset()
+	alerts.Reset()
+	r.DeregisterSink("file")
+	r.DeregisterSink("unknown")
+	r.Error("error")
+	require.Empty(t, file.String())
+	require.Equal(t, "ERRO error\n", alerts.String())
+}
+
+func TestSinkRouter_replace(t *testing.T) {
+	var a, b bytes.Buffer
+	r := NewSinkRouter(New(nil))
+	r.RegisterSink("main", &a, InfoLevel)
+	r.RegisterSink("main", &b, WarnLevel)
+	r.Info("info")
+	r.Warn("warn")
+	require.Empty(t, a.String())
+	require.Equal(t, "WARN warn\n", b.String())
+}
+
+func TestSinkRouter_noSinks(t *testing.T) {
+	r := NewSinkRouter(New(nil))
+	require.NotPanics(t, func() { r.Info("info") })
+}
+
+func TestSinkRouter_error(t *testing.T) {
+	var buf, fallback bytes.Buffer
+	r := NewSinkRouter(New(nil, WithFallbackWriter(&fallback)))
+	r.RegisterSink("broken", &failWriter{fail: true}, InfoLevel)
+	r.RegisterSink("good", &buf, InfoLevel)
+	r.Info("info")
+	require.Equal(t, "INFO info\n", buf.String())
+	require.Equal(t, "INFO info\n", fallback.String())
+}
+
+func TestSinkRouter_wrapped(t *testing.T) {
+	var file, alerts bytes.Buffer
+	r := NewSinkRouter(New(nil))
+	r.RegisterSink("file", &file, InfoLevel)
+	r.RegisterSink("alerts", &alerts, ErrorLevel)
+	WithRetryWriter(2, 0)(r.Logger)
+	WithExponentialBackoffOnError(time.Second, time.Second)(r.Logger)
+	l := NewAuditLogger(r.WithCircuitBreaker(1, time.Second), []byte("key"))
+	tl := NewTUILogger(l, nil)
+
+	tl.Info("info")
+	tl.Suspend()
+	tl.Error("error")
+	require.NoError(t, tl.Resume())
+	require.Len(t, strings.Split(file.String(), "\n"), 3)
+	require.True(t, strings.HasPrefix(alerts.String(), "ERRO error sig="), alerts.String())
+	require.Equal(t, 1, strings.Count(alerts.String(), "\n"))
+}
+
+func TestSinkRouter_close(t *testing.T) {
+	var a closeBuffer
+	var b bytes.Buffer
+	bw := bufio.NewWriter(&b)
+	r := NewSinkRouter(New(nil))
+	r.RegisterSink("a", &a, InfoLevel)
+	r.RegisterSink("b", bw, InfoLevel)
+	r.RegisterSink("stderr", os.Stderr, FatalLevel)
+	r.Info("info")
+	require.Empty(t, b.String())
+	require.NoError(t, r.Flush())
+	require.Equal(t, "INFO info\n", b.String())
+	require.NoError(t, r.Close())
+	require.True(t, a.closed)
+}
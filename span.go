@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// OperationKey is the key of the field holding the name of the operation of
+// loggers created with SpanStart.
+var OperationKey = "operation"
+
+// SpanStart starts timing the operation name. It returns a context holding a
+// child logger with the operation name, see FromContext, and a function
+// finishing the operation. The finish function logs the duration of the
+// operation at info level, or at error level along with err if it's not nil.
+// Only its first call logs.
+//
+//	ctx, finish := logger.SpanStart(ctx, "sync")
+//	err := sync(ctx)
+//	finish(err)
+//
+// If the logger has a context extractor, see WithContextExtractor, the child
+// logger extracts its fields from ctx. This correlates entries with the
+// current trace, like with logotel.SpanContext for OpenTelemetry.
+func (l *Logger) SpanStart(ctx context.Context, name string) (context.Context, func(error)) {
+	child := l.With(OperationKey, name)
+	if l.ctxExtractor != nil {
+		child = child.WithAutoContext(ctx)
+	}
+	start := time.Now()
+
+	var done uint32
+	return WithContext(ctx, child), func(err error) {
+		if !atomic.CompareAndSwapUint32(&done, 0, 1) {
+			return
+		}
+		d := time.Since(start)
+		if err != nil {
+			child.logDepth(1, ErrorLevel, "operation failed", "duration", d, ErrorKey, err)
+			return
+		}
+		child.logDepth(1, InfoLevel, "operation finished", "duration", d)
+	}
+}
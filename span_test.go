@@ -0,0 +1,60 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanStart(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetReportCaller(true)
+
+	ctx, finish := l.SpanStart(context.Background(), "sync")
+	FromContext(ctx).Info("syncing")
+	require.Equal(t, fmt.Sprintf("INFO <log/span_test.go:%d> syncing operation=sync\n", line(t)-1), buf.String())
+
+	buf.Reset()
+	time.Sleep(time.Millisecond)
+	_, _, line, _ := runtime.Caller(0)
+	finish(nil)
+	finish(errors.New("ignored"))
+	require.Regexp(t, fmt.Sprintf(`^INFO <log/span_test.go:%d> operation finished operation=sync duration=\S+\n$`, line+1), buf.String())
+}
+
+// line returns the line of its caller.
+func line(t *testing.T) int {
+	t.Helper()
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestSpanStart_error(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	_, finish := l.SpanStart(context.Background(), "sync")
+	finish(errors.New("boom"))
+	require.Regexp(t, `^ERRO operation failed operation=sync duration=\S+ err=boom\n$`, buf.String())
+}
+
+func TestSpanStart_contextExtractor(t *testing.T) {
+	type traceKey struct{}
+	var buf bytes.Buffer
+	l := New(&buf, WithContextExtractor(func(ctx context.Context) []interface{} {
+		if id, ok := ctx.Value(traceKey{}).(string); ok {
+			return []interface{}{"trace_id", id}
+		}
+		return nil
+	}))
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc")
+	ctx, _ = l.SpanStart(ctx, "sync")
+	FromContext(ctx).Info("syncing")
+	require.Equal(t, "INFO syncing operation=sync trace_id=abc\n", buf.String())
+}
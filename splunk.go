@@ -0,0 +1,236 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultSplunkRetries = 3
+	defaultSplunkBackoff = 100 * time.Millisecond
+	splunkHealthPath     = "/services/collector/health"
+)
+
+// splunkConfig is the configuration of a Splunk HEC writer.
+type splunkConfig struct {
+	client     *http.Client
+	host       string
+	source     string
+	sourcetype string
+	index      string
+	retries    int
+	backoff    time.Duration
+	batch      []BatchOption
+}
+
+// SplunkOption is an option for a Splunk HEC writer.
+type SplunkOption func(*splunkConfig)
+
+// WithSplunkClient returns a Splunk option that sends events with client,
+// instead of http.DefaultClient.
+func WithSplunkClient(client *http.Client) SplunkOption {
+	return func(c *splunkConfig) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithSplunkHost returns a Splunk option that sets the host of the events,
+// instead of the hostname of the machine.
+func WithSplunkHost(host string) SplunkOption {
+	return func(c *splunkConfig) {
+		c.host = host
+	}
+}
+
+// WithSplunkSource returns a Splunk option that sets the source of the
+// events.
+func WithSplunkSource(source string) SplunkOption {
+	return func(c *splunkConfig) {
+		c.source = source
+	}
+}
+
+// WithSplunkSourcetype returns a Splunk option that sets the source type of
+// the events, instead of "_json".
+func WithSplunkSourcetype(sourcetype string) SplunkOption {
+	return func(c *splunkConfig) {
+		c.sourcetype = sourcetype
+	}
+}
+
+// WithSplunkIndex returns a Splunk option that sends the events to index,
+// instead of the default index of the token.
+func WithSplunkIndex(index string) SplunkOption {
+	return func(c *splunkConfig) {
+		c.index = index
+	}
+}
+
+// WithSplunkRetry returns a Splunk option that retries a batch up to retries
+// times when the collector responds with a server error, waiting backoff
+// before the first retry and twice as long before each of the next ones. The
+// default is 3 retries, starting with 100ms.
+func WithSplunkRetry(retries int, backoff time.Duration) SplunkOption {
+	return func(c *splunkConfig) {
+		if retries >= 0 {
+			c.retries = retries
+		}
+		if backoff > 0 {
+			c.backoff = backoff
+		}
+	}
+}
+
+// WithSplunkBatchSize returns a Splunk option that sends events once n of
+// them are pending.
+func WithSplunkBatchSize(n int) SplunkOption {
+	return func(c *splunkConfig) {
+		c.batch = append(c.batch, WithBatchSize(n))
+	}
+}
+
+// WithSplunkFlushInterval returns a Splunk option that sends pending events
+// every interval, regardless of their number.
+func WithSplunkFlushInterval(interval time.Duration) SplunkOption {
+	return func(c *splunkConfig) {
+		c.batch = append(c.batch, WithBatchInterval(interval))
+	}
+}
+
+// NewSplunkHECWriter returns a writer that sends log entries to the Splunk
+// HTTP Event Collector at hecURL, usually ending with
+// "/services/collector/event", authenticated with token.
+//
+// The token is validated with the health endpoint of the collector before
+// returning. Each event holds the time, host, source, and source type
+// metadata, and the entry level, prefix, caller, message, and fields as a
+// JSON object. Entries can be written with the text or the JSON formatter,
+// and are sent in batches, retried with exponential backoff on server errors.
+//
+// Close the writer to send the pending events and stop batching.
+func NewSplunkHECWriter(hecURL, token string, opts ...SplunkOption) (io.WriteCloser, error) {
+	u, err := url.Parse(hecURL)
+	if err != nil {
+		return nil, fmt.Errorf("splunk: invalid HEC URL: %w", err)
+	}
+	c := splunkConfig{
+		client:     http.DefaultClient,
+		sourcetype: "_json",
+		retries:    defaultSplunkRetries,
+		backoff:    defaultSplunkBackoff,
+	}
+	c.host, _ = os.Hostname()
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	health := url.URL{Scheme: u.Scheme, Host: u.Host, Path: splunkHealthPath}
+	if err := splunkRequest(c.client, http.MethodGet, health.String(), token, nil); err != nil {
+		return nil, fmt.Errorf("splunk: health check: %w", err)
+	}
+
+	return newBatcher(func(entries []LogEntry) error {
+		return sendSplunk(&c, hecURL, token, entries)
+	}, c.batch...), nil
+}
+
+// splunkEvent is an event of the HTTP Event Collector.
+type splunkEvent struct {
+	Time       float64                `json:"time,omitempty"`
+	Host       string                 `json:"host,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	Sourcetype string                 `json:"sourcetype,omitempty"`
+	Index      string                 `json:"index,omitempty"`
+	Event      map[string]interface{} `json:"event"`
+}
+
+// sendSplunk sends entries to the collector in a single request, retrying
+// on server errors.
+func sendSplunk(c *splunkConfig, hecURL, token string, entries []LogEntry) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range entries {
+		ev := splunkEvent{
+			Host:       c.host,
+			Source:     c.source,
+			Sourcetype: c.sourcetype,
+			Index:      c.index,
+			Event:      fieldsMap(e.Fields),
+		}
+		if !e.Time.IsZero() {
+			ev.Time = float64(e.Time.UnixNano()/int64(time.Millisecond)) / 1000
+		}
+		if e.Level != noLevel {
+			ev.Event[LevelKey] = e.Level.String()
+		}
+		if e.Prefix != "" {
+			ev.Event[PrefixKey] = e.Prefix
+		}
+		if e.Caller != "" {
+			ev.Event[CallerKey] = e.Caller
+		}
+		if e.Message != "" {
+			ev.Event[MessageKey] = e.Message
+		}
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := splunkRequest(c.client, http.MethodPost, hecURL, token, body.Bytes())
+		se, ok := err.(*splunkError)
+		if !ok || se.code < 500 || attempt >= c.retries {
+			if err != nil {
+				return fmt.Errorf("splunk: %w", err)
+			}
+			return nil
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// splunkError is an error response of the collector.
+type splunkError struct {
+	status string
+	code   int
+	text   string
+}
+
+func (e *splunkError) Error() string {
+	return e.status + ": " + e.text
+}
+
+// splunkRequest sends a request to the collector, and returns a
+// *splunkError if it doesn't succeed.
+func splunkRequest(client *http.Client, method, url, token string, body []byte) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Splunk "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &splunkError{status: resp.Status, code: resp.StatusCode, text: string(bytes.TrimSpace(msg))}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// splunkServer is a fake HTTP Event Collector.
+type splunkServer struct {
+	mu       sync.Mutex
+	token    string
+	failures int
+	posts    int
+	events   []splunkEvent
+}
+
+func (s *splunkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("Authorization") != "Splunk "+s.token {
+		http.Error(w, `{"text":"Invalid token","code":4}`, http.StatusForbidden)
+		return
+	}
+	switch r.URL.Path {
+	case splunkHealthPath:
+		w.WriteHeader(http.StatusOK)
+	case "/services/collector/event":
+		s.posts++
+		if s.failures > 0 {
+			s.failures--
+			http.Error(w, `{"text":"Server is busy","code":9}`, http.StatusServiceUnavailable)
+			return
+		}
+		d := json.NewDecoder(r.Body)
+		for d.More() {
+			var ev splunkEvent
+			if err := d.Decode(&ev); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.events = append(s.events, ev)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSplunkHECWriter(t *testing.T) {
+	s := &splunkServer{token: "secret", failures: 2}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w, err := NewSplunkHECWriter(srv.URL+"/services/collector/event", "secret",
+		WithSplunkHost("host-1"),
+		WithSplunkSource("api"),
+		WithSplunkIndex("main"),
+		WithSplunkRetry(2, time.Millisecond),
+		WithSplunkFlushInterval(time.Hour),
+	)
+	require.NoError(t, err)
+
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 250*int(time.Millisecond), time.UTC)
+	l := NewWithOptions(w, Options{
+		ReportTimestamp: true,
+		TimeFunction:    func() time.Time { return ts },
+		TimeFormat:      time.RFC3339Nano,
+		Prefix:          "app",
+	})
+	l.Info("hello", "foo", "bar")
+	l.Error("failed")
+	require.NoError(t, w.Close())
+
+	require.Equal(t, 3, s.posts)
+	sec := float64(ts.Unix()) + 0.25
+	require.Equal(t, []splunkEvent{
+		{
+			Time: sec, Host: "host-1", Source: "api", Sourcetype: "_json", Index: "main",
+			Event: map[string]interface{}{"lvl": "info", "prefix": "app", "msg": "hello", "foo": "bar"},
+		},
+		{
+			Time: sec, Host: "host-1", Source: "api", Sourcetype: "_json", Index: "main",
+			Event: map[string]interface{}{"lvl": "error", "prefix": "app", "msg": "failed"},
+		},
+	}, s.events)
+}
+
+func TestSplunkHECWriter_retriesExhausted(t *testing.T) {
+	s := &splunkServer{token: "secret", failures: 10}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	w, err := NewSplunkHECWriter(srv.URL+"/services/collector/event", "secret",
+		WithSplunkRetry(1, time.Millisecond),
+		WithSplunkBatchSize(1),
+	)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("INFO hello\n"))
+	require.EqualError(t, err, `splunk: 503 Service Unavailable: {"text":"Server is busy","code":9}`)
+	require.Equal(t, 2, s.posts)
+	require.NoError(t, w.Close())
+}
+
+func TestSplunkHECWriter_invalidToken(t *testing.T) {
+	srv := httptest.NewServer(&splunkServer{token: "secret"})
+	defer srv.Close()
+
+	_, err := NewSplunkHECWriter(srv.URL+"/services/collector/event", "wrong")
+	require.EqualError(t, err, `splunk: health check: 403 Forbidden: {"text":"Invalid token","code":4}`)
+}
@@ -0,0 +1,78 @@
+package log
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// sqlTableRe matches valid SQL table names, optionally qualified with a
+// schema.
+var sqlTableRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// NewSQLWriter returns a writer that inserts log entries into table of db.
+//
+// The table must have the columns timestamp, level, prefix, caller, message,
+// and fields, usually along with an auto-incremented id column. The fields
+// are stored as a JSON object. Entries can be written with the text or the
+// JSON formatter, and are inserted in batches, in a single transaction. The
+// insert statement uses "?" placeholders.
+//
+// Close the writer to insert the pending entries and stop batching.
+func NewSQLWriter(db *sql.DB, table string, opts ...BatchOption) (io.WriteCloser, error) {
+	if !sqlTableRe.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO %s (timestamp, level, prefix, caller, message, fields) VALUES (?, ?, ?, ?, ?, ?)",
+		table,
+	)
+	return newBatcher(func(entries []LogEntry) error {
+		return insertEntries(db, query, entries)
+	}, opts...), nil
+}
+
+// insertEntries inserts entries with query in a single transaction.
+func insertEntries(db *sql.DB, query string, entries []LogEntry) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range entries {
+		var ts interface{}
+		if !e.Time.IsZero() {
+			ts = e.Time
+		}
+		fields, err := json.Marshal(fieldsMap(e.Fields))
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(ts, e.Level.String(), e.Prefix, e.Caller, e.Message, string(fields)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// fieldsMap converts key-value pairs into a map.
+func fieldsMap(fields []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		m[fmt.Sprint(fields[i])] = fields[i+1]
+	}
+	return m
+}
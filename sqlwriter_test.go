@@ -0,0 +1,117 @@
+package log
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver is a database/sql driver that records executed statements.
+type fakeDriver struct {
+	mu      sync.Mutex
+	queries []string
+	args    [][]driver.Value
+	commits int
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{d: c.d}, nil }
+
+type fakeTx struct{ d *fakeDriver }
+
+func (t *fakeTx) Commit() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.commits++
+	return nil
+}
+
+func (t *fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+var fakeDrivers sync.Map
+
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	name := "fake-" + t.Name()
+	v, loaded := fakeDrivers.LoadOrStore(name, &fakeDriver{})
+	d := v.(*fakeDriver)
+	if loaded {
+		// The driver stays registered across runs of the test, like with
+		// -count.
+		d.mu.Lock()
+		d.queries, d.args, d.commits = nil, nil, 0
+		d.mu.Unlock()
+	} else {
+		sql.Register(name, d)
+	}
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, d
+}
+
+func TestSQLWriter(t *testing.T) {
+	db, d := openFakeDB(t)
+	w, err := NewSQLWriter(db, "logs", WithBatchSize(2), WithBatchInterval(time.Hour))
+	require.NoError(t, err)
+
+	ts := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	l := NewWithOptions(w, Options{
+		ReportTimestamp: true,
+		TimeFunction:    func() time.Time { return ts },
+		Prefix:          "app",
+	})
+	l.Info("hello", "foo", "bar")
+	l.Error("failed", "err", "boom")
+	l.Warn("pending")
+	require.Equal(t, 1, d.commits)
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, d.commits)
+
+	query := "INSERT INTO logs (timestamp, level, prefix, caller, message, fields) VALUES (?, ?, ?, ?, ?, ?)"
+	require.Equal(t, []string{query, query, query}, d.queries)
+	require.Equal(t, [][]driver.Value{
+		{ts, "info", "app", "", "hello", `{"foo":"bar"}`},
+		{ts, "error", "app", "", "failed", `{"err":"boom"}`},
+		{ts, "warn", "app", "", "pending", `{}`},
+	}, d.args)
+}
+
+func TestSQLWriter_invalidTable(t *testing.T) {
+	db, _ := openFakeDB(t)
+	_, err := NewSQLWriter(db, "logs; DROP TABLE users")
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package log
+
+import (
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// logDir is the directory of the logger sources, used to recognize logger
+// frames.
+var logDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}()
+
+// WithStackOnError returns a logger option that adds the call stack of the
+// logging call to entries at error level and above, under StackKey, unless
+// they already have a stack. The stack is condensed to one line per frame,
+// like "main.handle (app/server.go:42)", and only has the frames of the
+// program: runtime and logger frames are left out.
+func WithStackOnError() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.stackOnError = true
+	}
+}
+
+// callStack returns the condensed call stack, starting skip frames above the
+// caller of callStack.
+func callStack(skip int) string {
+	var pcs [32]uintptr
+	// Skip runtime.Callers and callStack.
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	var lines []string
+	for {
+		f, more := frames.Next()
+		if !isInternalFrame(f) {
+			lines = append(lines, trimFuncName(f.Function)+" ("+trimCallerPath(f.File, 2)+":"+strconv.Itoa(f.Line)+")")
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isInternalFrame returns true if f is a runtime or logger frame.
+func isInternalFrame(f runtime.Frame) bool {
+	if strings.HasPrefix(f.Function, "runtime.") {
+		return true
+	}
+	return path.Dir(f.File) == logDir && !strings.HasSuffix(f.File, "_test.go")
+}
+
+// hasKey returns true if keyvals has the given key.
+func hasKey(keyvals []interface{}, key string) bool {
+	for i := 0; i < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStackOnError(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithStackOnError())
+	l.SetFormatter(JSONFormatter)
+
+	l.Warn("warn")
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.NotContains(t, m, StackKey)
+
+	buf.Reset()
+	_, _, line, _ := runtime.Caller(0)
+	l.With("foo", "bar").Error("error")
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	frames := strings.Split(m[StackKey].(string), "\n")
+	require.Equal(t, fmt.Sprintf("TestWithStackOnError (log/stack_test.go:%d)", line+1), frames[0])
+	require.True(t, strings.HasPrefix(frames[1], "tRunner (testing/testing.go:"), frames[1])
+	for _, f := range frames {
+		require.NotContains(t, f, "runtime")
+		require.NotContains(t, f, "logger.go")
+	}
+}
+
+func TestWithStackOnError_existingStack(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithStackOnError())
+	l.Error("error", StackKey, "custom")
+	require.Equal(t, "ERRO error stack=custom\n", buf.String())
+}
+
+func TestWithStackOnError_helper(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithStackOnError())
+	l.SetFormatter(JSONFormatter)
+	logError := func() {
+		l.Error("error")
+	}
+	logError()
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	frames := strings.Split(m[StackKey].(string), "\n")
+	require.True(t, strings.HasPrefix(frames[0], "TestWithStackOnError_helper.func1 (log/stack_test.go:"), frames[0])
+	require.True(t, strings.HasPrefix(frames[1], "TestWithStackOnError_helper (log/stack_test.go:"), frames[1])
+}
@@ -1,9 +1,10 @@
 package log
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"strings"
-	"sync"
 )
 
 type stdLogWriter struct {
@@ -14,34 +15,32 @@ type stdLogWriter struct {
 func (l *stdLogWriter) Write(p []byte) (n int, err error) {
 	str := strings.TrimSuffix(string(p), "\n")
 
+	level := InfoLevel
 	if l.opt != nil {
 		switch l.opt.ForceLevel {
-		case DebugLevel:
-			l.l.Debug(str)
-		case InfoLevel:
-			l.l.Info(str)
-		case WarnLevel:
-			l.l.Warn(str)
-		case ErrorLevel:
-			l.l.Error(str)
+		case DebugLevel, InfoLevel, WarnLevel, ErrorLevel:
+			level = l.opt.ForceLevel
+		default:
+			return len(p), nil
 		}
 	} else {
 		switch {
 		case strings.HasPrefix(str, "DEBUG"):
-			l.l.Debug(strings.TrimSpace(str[5:]))
+			level, str = DebugLevel, strings.TrimSpace(str[5:])
 		case strings.HasPrefix(str, "INFO"):
-			l.l.Info(strings.TrimSpace(str[4:]))
+			level, str = InfoLevel, strings.TrimSpace(str[4:])
 		case strings.HasPrefix(str, "WARN"):
-			l.l.Warn(strings.TrimSpace(str[4:]))
+			level, str = WarnLevel, strings.TrimSpace(str[4:])
 		case strings.HasPrefix(str, "ERROR"):
-			l.l.Error(strings.TrimSpace(str[5:]))
+			level, str = ErrorLevel, strings.TrimSpace(str[5:])
 		case strings.HasPrefix(str, "ERR"):
-			l.l.Error(strings.TrimSpace(str[3:]))
-		default:
-			l.l.Info(str)
+			level, str = ErrorLevel, strings.TrimSpace(str[3:])
 		}
 	}
 
+	// The caller stack is
+	// log.Printf() -> l.Output() -> l.out.Write(stdLogger.Write)
+	l.l.logDepth(3, level, str)
 	return len(p), nil
 }
 
@@ -52,19 +51,113 @@ type StandardLogOptions struct {
 
 // StandardLog returns a standard logger from Logger. The returned logger
 // can infer log levels from message prefix. Expected prefixes are DEBUG, INFO,
-// WARN, ERROR, and ERR.
+// WARN, ERROR, and ERR. It writes through l, so later changes to l, such as
+// SetOutput, apply to it too.
 func (l *Logger) StandardLog(opts ...StandardLogOptions) *log.Logger {
-	nl := *l
-	nl.mu = &sync.RWMutex{}
-	nl.helpers = &sync.Map{}
-	// The caller stack is
-	// log.Printf() -> l.Output() -> l.out.Write(stdLogger.Write)
-	nl.callerOffset += 3
 	sl := &stdLogWriter{
-		l: &nl,
+		l: l,
 	}
 	if len(opts) > 0 {
 		sl.opt = &opts[0]
 	}
 	return log.New(sl, "", 0)
 }
+
+// stdLog is an alias so that log.Logger can be embedded next to Logger.
+type stdLog = log.Logger
+
+// StdLogger is a Logger that also provides the standard library log.Logger
+// API. Print, Fatal, and Panic calls, and their variants, are routed to the
+// Logger with no level, fatal level, and no level respectively. The
+// remaining log.Logger methods use the standard log adapter, see
+// StandardLog.
+type StdLogger struct {
+	*Logger
+	*stdLog
+}
+
+// NewStdLogger returns a new StdLogger that routes to l.
+func NewStdLogger(l *Logger, opts ...StandardLogOptions) *StdLogger {
+	return &StdLogger{
+		Logger: l,
+		stdLog: l.StandardLog(opts...),
+	}
+}
+
+// Print prints a message with no level. Arguments are handled in the manner
+// of fmt.Print.
+func (s *StdLogger) Print(v ...interface{}) {
+	s.Logger.log(noLevel, fmt.Sprint(v...))
+}
+
+// Printf prints a message with no level and formatting.
+func (s *StdLogger) Printf(format string, v ...interface{}) {
+	s.Logger.log(noLevel, fmt.Sprintf(format, v...))
+}
+
+// Println prints a message with no level. Arguments are handled in the
+// manner of fmt.Println.
+func (s *StdLogger) Println(v ...interface{}) {
+	s.Logger.log(noLevel, sprintln(v...))
+}
+
+// Fatal prints a fatal message and exits. Arguments are handled in the manner
+// of fmt.Print.
+func (s *StdLogger) Fatal(v ...interface{}) {
+	msg := fmt.Sprint(v...)
+	s.Logger.log(FatalLevel, msg)
+	s.Logger.exit(msg)
+}
+
+// Fatalf prints a fatal message with formatting and exits.
+func (s *StdLogger) Fatalf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	s.Logger.log(FatalLevel, msg)
+	s.Logger.exit(msg)
+}
+
+// Fatalln prints a fatal message and exits. Arguments are handled in the
+// manner of fmt.Println.
+func (s *StdLogger) Fatalln(v ...interface{}) {
+	msg := sprintln(v...)
+	s.Logger.log(FatalLevel, msg)
+	s.Logger.exit(msg)
+}
+
+// Panic prints a message with no level and panics. Arguments are handled in
+// the manner of fmt.Print.
+func (s *StdLogger) Panic(v ...interface{}) {
+	msg := fmt.Sprint(v...)
+	s.Logger.log(noLevel, msg)
+	panic(msg)
+}
+
+// Panicf prints a message with no level and formatting, and panics.
+func (s *StdLogger) Panicf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	s.Logger.log(noLevel, msg)
+	panic(msg)
+}
+
+// Panicln prints a message with no level and panics. Arguments are handled
+// in the manner of fmt.Println.
+func (s *StdLogger) Panicln(v ...interface{}) {
+	msg := sprintln(v...)
+	s.Logger.log(noLevel, msg)
+	panic(msg)
+}
+
+// SetOutput sets the output destination of the Logger.
+func (s *StdLogger) SetOutput(w io.Writer) {
+	s.Logger.SetOutput(w)
+}
+
+// SetPrefix sets the prefix of the Logger.
+func (s *StdLogger) SetPrefix(prefix string) {
+	s.Logger.SetPrefix(prefix)
+}
+
+// sprintln is fmt.Sprintln without the trailing newline.
+func sprintln(v ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+}
@@ -117,3 +117,83 @@ func TestStdLog_writer(t *testing.T) {
 		})
 	}
 }
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(New(&buf))
+	cases := []struct {
+		name     string
+		expected string
+		f        func()
+	}{
+		{
+			name:     "printf",
+			expected: "starting: foo\n",
+			f:        func() { l.Printf("starting: %s", "foo") },
+		},
+		{
+			name:     "print",
+			expected: "foo1 2\n",
+			f:        func() { l.Print("foo", 1, 2) },
+		},
+		{
+			name:     "println",
+			expected: "foo 1 2\n",
+			f:        func() { l.Println("foo", 1, 2) },
+		},
+		{
+			name:     "info",
+			expected: "INFO info foo=bar\n",
+			f:        func() { l.Info("info", "foo", "bar") },
+		},
+		{
+			name:     "prefix",
+			expected: "app: print\n",
+			f: func() {
+				l.SetPrefix("app")
+				defer l.SetPrefix("")
+				l.Print("print")
+			},
+		},
+		{
+			name:     "panic",
+			expected: "panic\n",
+			f: func() {
+				assert.PanicsWithValue(t, "panic", func() { l.Panic("panic") })
+			},
+		},
+		{
+			name:     "std output",
+			expected: "WARN std\n",
+			f:        func() { _ = l.Output(1, "WARN std") },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			c.f()
+			assert.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestStdLogger_caller(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(New(&buf))
+	l.SetReportCaller(true)
+	_, file, line, ok := runtime.Caller(0)
+	require.True(t, ok)
+	l.Printf("%s", "coffee")
+	assert.Equal(t, fmt.Sprintf("<log/%s:%d> coffee\n", filepath.Base(file), line+2), buf.String())
+}
+
+func TestStdLogger_setOutput(t *testing.T) {
+	var a, b bytes.Buffer
+	l := NewStdLogger(New(&a))
+	l.SetOutput(&b)
+	l.SetPrefix("app")
+	_ = l.Output(1, "WARN std")
+	_, _ = l.Writer().Write([]byte("writer\n"))
+	assert.Empty(t, a.String())
+	assert.Equal(t, "WARN app: std\nINFO app: writer\n", b.String())
+}
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogErrorKey is the key of the field describing invalid keys when strict keys
+// are enabled.
+var LogErrorKey = "log_error"
+
+// isValidKey returns true if key is a non-empty string made of letters,
+// digits, underscores, dashes, and dots.
+func isValidKey(key interface{}) bool {
+	s, ok := key.(string)
+	if !ok || s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateKeys replaces invalid keys in keyvals with "INVALID_KEY_N", where N
+// is the index of the key-value pair, and appends a field describing the
+// invalid keys.
+func validateKeys(keyvals []interface{}) []interface{} {
+	var errs []string
+	for i := 0; i < len(keyvals); i += 2 {
+		if isValidKey(keyvals[i]) {
+			continue
+		}
+		errs = append(errs, fmt.Sprintf("invalid key %q", fmt.Sprint(keyvals[i])))
+		keyvals[i] = fmt.Sprintf("INVALID_KEY_%d", i/2)
+	}
+	if len(errs) > 0 {
+		keyvals = append(keyvals, LogErrorKey, strings.Join(errs, ", "))
+	}
+	return keyvals
+}
+
+// WithStrictKeys returns a logger option that validates keys at log time.
+// Keys must be non-empty strings made of letters, digits, underscores,
+// dashes, and dots. Invalid keys are replaced with "INVALID_KEY_N", where N
+// is the index of the key-value pair, and a "log_error" field describing the
+// invalid keys is appended to the entry.
+func WithStrictKeys() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.strictKeys = true
+	}
+}
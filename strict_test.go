@@ -0,0 +1,47 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithStrictKeys())
+	cases := []struct {
+		name     string
+		expected string
+		kvs      []interface{}
+	}{
+		{
+			name:     "valid keys",
+			expected: "INFO info foo=bar http.status_code=200 x-id=1\n",
+			kvs:      []interface{}{"foo", "bar", "http.status_code", 200, "x-id", 1},
+		},
+		{
+			name:     "key with space",
+			expected: "INFO info foo=bar INVALID_KEY_1=baz log_error=\"invalid key \\\"foo bar\\\"\"\n",
+			kvs:      []interface{}{"foo", "bar", "foo bar", "baz"},
+		},
+		{
+			name:     "empty and non-string keys",
+			expected: "INFO info INVALID_KEY_0=foo INVALID_KEY_1=bar log_error=\"invalid key \\\"\\\", invalid key \\\"1\\\"\"\n",
+			kvs:      []interface{}{"", "foo", 1, "bar"},
+		},
+		{
+			name:     "error key",
+			expected: "INFO info INVALID_KEY_0=foo log_error=\"invalid key \\\"bad\\\"\"\n",
+			kvs:      []interface{}{errors.New("bad"), "foo"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf.Reset()
+			l.Info("info", c.kvs...)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
@@ -102,3 +102,62 @@ func levelStyle(level Level) lipgloss.Style {
 		return lipgloss.NewStyle()
 	}
 }
+
+// SetKeyStyle sets the style of key for this logger and the loggers derived
+// from it afterwards. It takes precedence over KeyStyles and KeyStyle.
+func (l *Logger) SetKeyStyle(key string, style lipgloss.Style) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keyStyles = withStyle(l.keyStyles, key, style)
+}
+
+// SetValueStyle sets the style of the values of key for this logger and the
+// loggers derived from it afterwards. It takes precedence over ValueStyles
+// and ValueStyle.
+func (l *Logger) SetValueStyle(key string, style lipgloss.Style) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.valueStyles = withStyle(l.valueStyles, key, style)
+}
+
+// withStyle returns a copy of styles with the style of key set. Style maps
+// are shared between loggers derived from each other, so they're never
+// modified in place.
+func withStyle(styles map[string]lipgloss.Style, key string, style lipgloss.Style) map[string]lipgloss.Style {
+	m := make(map[string]lipgloss.Style, len(styles)+1)
+	for k, v := range styles {
+		m[k] = v
+	}
+	m[key] = style
+	return m
+}
+
+// keyStyle returns the style of key.
+func (l *Logger) keyStyle(key string) lipgloss.Style {
+	if s, ok := l.keyStyles[key]; ok {
+		return s
+	}
+	if s, ok := KeyStyles[key]; ok {
+		return s
+	}
+	return KeyStyle
+}
+
+// levelStyle returns the style of level.
+func (l *Logger) levelStyle(level Level) lipgloss.Style {
+	if s, ok := l.levelStyles[level]; ok {
+		return s
+	}
+	return levelStyle(level)
+}
+
+// valueStyle returns the style of the values of key.
+func (l *Logger) valueStyle(key string) lipgloss.Style {
+	if s, ok := l.valueStyles[key]; ok {
+		return s
+	}
+	if s, ok := ValueStyles[key]; ok {
+		return s
+	}
+	return ValueStyle
+}
@@ -0,0 +1,79 @@
+package log
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// printKey is the key of the counter of entries without a level in
+// summaries.
+const printKey = "print"
+
+// levelCounters counts the entries written at each level, from DebugLevel to
+// noLevel.
+type levelCounters [noLevel - DebugLevel + 1]int64
+
+// add counts an entry at level. Levels without a counter are ignored.
+func (c *levelCounters) add(level Level) {
+	if level >= DebugLevel && level <= noLevel {
+		atomic.AddInt64(&c[level-DebugLevel], 1)
+	}
+}
+
+// fields returns the counters as key-value pairs.
+func (c *levelCounters) fields() []interface{} {
+	kvs := make([]interface{}, 0, 2*len(c))
+	for i := range c {
+		key := Level(i) + DebugLevel
+		name := key.String()
+		if key == noLevel {
+			name = printKey
+		}
+		kvs = append(kvs, name, atomic.LoadInt64(&c[i]))
+	}
+	return kvs
+}
+
+// Summary returns the number of entries written at each level since the
+// logger was created, or since the last call to ResetCounters, like
+// "debug=0 info=12 warn=1 error=0 fatal=0 print=3". Entries without a level,
+// written with Print, are counted as "print". The counters are shared with
+// the loggers derived from the logger, and with its parents.
+func (l *Logger) Summary() string {
+	if l.counters == nil {
+		return ""
+	}
+	kvs := l.counters.fields()
+	var b strings.Builder
+	for i := 0; i < len(kvs); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(kvs[i].(string))
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatInt(kvs[i+1].(int64), 10))
+	}
+	return b.String()
+}
+
+// WriteSummary logs the counters returned by Summary at info level, as the
+// fields of a "log summary" entry. It's meant to be called once the program
+// is done, before closing the logger.
+func (l *Logger) WriteSummary() {
+	var kvs []interface{}
+	if l.counters != nil {
+		kvs = l.counters.fields()
+	}
+	l.log(InfoLevel, "log summary", kvs...)
+}
+
+// ResetCounters resets the counters returned by Summary.
+func (l *Logger) ResetCounters() {
+	if l.counters == nil {
+		return
+	}
+	for i := range l.counters {
+		atomic.StoreInt64(&l.counters[i], 0)
+	}
+}
@@ -0,0 +1,38 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	require.Equal(t, "debug=0 info=0 warn=0 error=0 fatal=0 print=0", l.Summary())
+
+	l.Debug("filtered")
+	l.Info("one")
+	l.With("foo", "bar").Info("two")
+	l.Warn("three")
+	l.Error("four")
+	l.Print("five")
+	require.Equal(t, "debug=0 info=2 warn=1 error=1 fatal=0 print=1", l.Summary())
+
+	buf.Reset()
+	l.WriteSummary()
+	require.Equal(t, "INFO log summary debug=0 info=2 warn=1 error=1 fatal=0 print=1\n", buf.String())
+	require.Equal(t, "debug=0 info=3 warn=1 error=1 fatal=0 print=1", l.Summary())
+
+	l.ResetCounters()
+	require.Equal(t, "debug=0 info=0 warn=0 error=0 fatal=0 print=0", l.Summary())
+	l.Log(Level(42), "custom")
+	require.Equal(t, "debug=0 info=0 warn=0 error=0 fatal=0 print=0", l.Summary())
+}
+
+func TestSummary_noCounters(t *testing.T) {
+	l := &Logger{}
+	require.Empty(t, l.Summary())
+	require.NotPanics(t, l.ResetCounters)
+}
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -8,11 +9,14 @@ import (
 	"time"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 const (
 	separator       = "="
 	indentSeparator = "  │ "
+	elidedSeparator = "│"
 )
 
 func (l *Logger) writeIndent(w io.Writer, str string, indent string, newline bool, key string) {
@@ -23,11 +27,7 @@ func (l *Logger) writeIndent(w io.Writer, str string, indent string, newline boo
 			if str != "" {
 				_, _ = w.Write([]byte(indent))
 				val := escapeStringForOutput(str, false)
-				if valueStyle, ok := ValueStyles[key]; ok {
-					val = valueStyle.Renderer(l.re).Render(val)
-				} else {
-					val = ValueStyle.Renderer(l.re).Render(val)
-				}
+				val = l.valueStyle(key).Renderer(l.re).Render(val)
 				_, _ = w.Write([]byte(val))
 				if newline {
 					_, _ = w.Write([]byte{'\n'})
@@ -45,6 +45,16 @@ func (l *Logger) writeIndent(w io.Writer, str string, indent string, newline boo
 	}
 }
 
+// elidedTimestamp returns the continuation marker replacing a timestamp
+// identical to the previous one, padded to the width of the timestamp.
+func elidedTimestamp(ts string) string {
+	w := lipgloss.Width(ts)
+	if w <= 1 {
+		return elidedSeparator
+	}
+	return strings.Repeat(" ", w-1) + elidedSeparator
+}
+
 func needsEscaping(str string) bool {
 	for _, b := range str {
 		if !unicode.IsPrint(b) || b == '"' {
@@ -142,40 +152,51 @@ func needsQuoting(str string) bool {
 	return false
 }
 
-func (l *Logger) textFormatter(keyvals ...interface{}) {
+func (l *Logger) textFormatter(b *bytes.Buffer, keyvals ...interface{}) {
 	for i := 0; i < len(keyvals); i += 2 {
 		switch keyvals[i] {
 		case TimestampKey:
 			if t, ok := keyvals[i+1].(time.Time); ok {
 				ts := t.Format(l.timeFormat)
+				if l.elideTimestamp {
+					ts = elidedTimestamp(ts)
+				}
 				ts = TimestampStyle.Renderer(l.re).Render(ts)
-				l.b.WriteString(ts)
-				l.b.WriteByte(' ')
+				b.WriteString(ts)
+				b.WriteByte(' ')
 			}
 		case LevelKey:
 			if level, ok := keyvals[i+1].(Level); ok {
-				lvl := levelStyle(level).Renderer(l.re).String()
-				l.b.WriteString(lvl)
-				l.b.WriteByte(' ')
+				lvl := l.levelStyle(level).Renderer(l.re).String()
+				if l.levelPadding {
+					lvl = l.padLevel(lvl)
+				}
+				b.WriteString(lvl)
+				b.WriteByte(' ')
 			}
 		case CallerKey:
 			if caller, ok := keyvals[i+1].(string); ok {
 				caller = fmt.Sprintf("<%s>", caller)
 				caller = CallerStyle.Renderer(l.re).Render(caller)
-				l.b.WriteString(caller)
-				l.b.WriteByte(' ')
+				b.WriteString(caller)
+				b.WriteByte(' ')
 			}
 		case PrefixKey:
 			if prefix, ok := keyvals[i+1].(string); ok {
 				prefix = PrefixStyle.Renderer(l.re).Render(prefix)
-				l.b.WriteString(prefix)
-				l.b.WriteByte(' ')
+				b.WriteString(prefix)
+				b.WriteByte(' ')
 			}
 		case MessageKey:
 			if msg := keyvals[i+1]; msg != nil {
 				m := fmt.Sprint(msg)
+				pad := l.minMsgLen - lipgloss.Width(m)
 				m = MessageStyle.Renderer(l.re).Render(m)
-				l.b.WriteString(m)
+				b.WriteString(m)
+				// Only pad messages followed by fields, to align them.
+				if pad > 0 && i < len(keyvals)-2 {
+					b.WriteString(strings.Repeat(" ", pad))
+				}
 			}
 		default:
 			sep := separator
@@ -193,15 +214,8 @@ func (l *Logger) textFormatter(keyvals ...interface{}) {
 				continue
 			}
 			actualKey := key
-			valueStyle := ValueStyle
-			if vs, ok := ValueStyles[actualKey]; ok {
-				valueStyle = vs
-			}
-			if keyStyle, ok := KeyStyles[key]; ok {
-				key = keyStyle.Renderer(l.re).Render(key)
-			} else {
-				key = KeyStyle.Renderer(l.re).Render(key)
-			}
+			valueStyle := l.valueStyle(actualKey)
+			key = l.keyStyle(key).Renderer(l.re).Render(key)
 
 			// Values may contain multiple lines, and that format
 			// is preserved, with each line prefixed with a "  | "
@@ -211,30 +225,30 @@ func (l *Logger) textFormatter(keyvals ...interface{}) {
 			// in the value string are "normal", like if they
 			// contain ANSI escape sequences.
 			if strings.Contains(val, "\n") {
-				l.b.WriteString("\n  ")
-				l.b.WriteString(key)
-				l.b.WriteString(sep + "\n")
-				l.writeIndent(&l.b, val, indentSep, moreKeys, actualKey)
+				b.WriteString("\n  ")
+				b.WriteString(key)
+				b.WriteString(sep + "\n")
+				l.writeIndent(b, val, indentSep, moreKeys, actualKey)
 				// If there are more keyvals, separate them with a space.
 				if moreKeys {
-					l.b.WriteByte(' ')
+					b.WriteByte(' ')
 				}
 			} else if !raw && needsQuoting(val) {
-				l.b.WriteByte(' ')
-				l.b.WriteString(key)
-				l.b.WriteString(sep)
-				l.b.WriteString(valueStyle.Renderer(l.re).Render(fmt.Sprintf(`"%s"`,
+				b.WriteByte(' ')
+				b.WriteString(key)
+				b.WriteString(sep)
+				b.WriteString(valueStyle.Renderer(l.re).Render(fmt.Sprintf(`"%s"`,
 					escapeStringForOutput(val, true))))
 			} else {
 				val = valueStyle.Renderer(l.re).Render(val)
-				l.b.WriteByte(' ')
-				l.b.WriteString(key)
-				l.b.WriteString(sep)
-				l.b.WriteString(val)
+				b.WriteByte(' ')
+				b.WriteString(key)
+				b.WriteString(sep)
+				b.WriteString(val)
 			}
 		}
 	}
 
 	// Add a newline to the end of the log message.
-	l.b.WriteByte('\n')
+	b.WriteByte('\n')
 }
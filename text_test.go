@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -396,3 +397,26 @@ func TestTextValueStyles(t *testing.T) {
 		})
 	}
 }
+
+func TestTextPerKeyStyles(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.re.SetColorProfile(termenv.ANSI256)
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	logger.SetKeyStyle("error", errStyle)
+	logger.SetValueStyle("error", errStyle.Copy().Bold(true))
+	child := logger.With()
+	logger.SetValueStyle("other", errStyle)
+
+	render := func(s lipgloss.Style, str string) string {
+		return s.Renderer(logger.re).Render(str)
+	}
+	child.Info("info", "error", "boom", "other", "ok")
+	expected := fmt.Sprintf("%s info %s%s%s %s%s%s\n",
+		InfoLevelStyle.Renderer(logger.re),
+		render(errStyle, "error"), render(SeparatorStyle, separator), render(errStyle.Copy().Bold(true), "boom"),
+		render(KeyStyle, "other"), render(SeparatorStyle, separator), render(ValueStyle, "ok"),
+	)
+	require.Equal(t, expected, buf.String())
+	require.Contains(t, buf.String(), "\x1b[")
+}
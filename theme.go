@@ -0,0 +1,68 @@
+package log
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ansi256Registry is a map of the renderers of the loggers using the ANSI
+// 256-color theme, by writer.
+var ansi256Registry = sync.Map{}
+
+// ansi256LevelColors are the colors of the levels in the ANSI 256-color theme.
+var ansi256LevelColors = map[Level]lipgloss.Color{
+	DebugLevel: "33",  // dodger blue
+	InfoLevel:  "42",  // spring green
+	WarnLevel:  "214", // orange
+	ErrorLevel: "196", // red
+	FatalLevel: "201", // magenta
+}
+
+// WithANSI256Theme returns a logger option that colors the levels with
+// distinct, vivid colors from the ANSI 256-color palette, and renders the
+// output with the ANSI 256-color profile whenever the output supports
+// colors. Unlike the default adaptive styles, the colors don't depend on the
+// terminal background or on whether truecolor is detected, so they're
+// consistent across terminals.
+func WithANSI256Theme() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		styles := make(map[Level]lipgloss.Style, len(ansi256LevelColors))
+		for level, color := range ansi256LevelColors {
+			styles[level] = lipgloss.NewStyle().
+				SetString(strings.ToUpper(level.String())).
+				Bold(true).
+				MaxWidth(4).
+				Foreground(color)
+		}
+		l.levelStyles = styles
+		l.ansi256 = true
+		l.re = l.renderer(l.w)
+		l.resetLevelWidth()
+	}
+}
+
+// ansi256Renderer returns the renderer of w using the ANSI 256-color profile,
+// or no colors at all if w doesn't support them.
+func ansi256Renderer(w io.Writer) *lipgloss.Renderer {
+	if v, ok := ansi256Registry.Load(w); ok {
+		return v.(*lipgloss.Renderer)
+	}
+	re := lipgloss.NewRenderer(w, termenv.WithColorCache(true))
+	re.SetColorProfile(ansi256Profile(re.ColorProfile()))
+	v, _ := ansi256Registry.LoadOrStore(w, re)
+	return v.(*lipgloss.Renderer)
+}
+
+// ansi256Profile returns the ANSI 256-color profile, unless p has no colors.
+func ansi256Profile(p termenv.Profile) termenv.Profile {
+	if p == termenv.Ascii {
+		return p
+	}
+	return termenv.ANSI256
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithANSI256Theme(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithANSI256Theme())
+
+	// Not a terminal, no colors.
+	l.Info("hello")
+	require.Equal(t, "INFO hello\n", buf.String())
+
+	l.re.SetColorProfile(termenv.ANSI256)
+	cases := []struct {
+		level    Level
+		expected string
+	}{
+		{DebugLevel, "\x1b[1;38;5;33mDEBU\x1b[0m"},
+		{InfoLevel, "\x1b[1;38;5;42mINFO\x1b[0m"},
+		{WarnLevel, "\x1b[1;38;5;214mWARN\x1b[0m"},
+		{ErrorLevel, "\x1b[1;38;5;196mERRO\x1b[0m"},
+		{FatalLevel, "\x1b[1;38;5;201mFATA\x1b[0m"},
+	}
+	for _, c := range cases {
+		t.Run(c.level.String(), func(t *testing.T) {
+			require.Equal(t, c.expected, l.levelStyle(c.level).Renderer(l.re).String())
+		})
+	}
+
+	buf.Reset()
+	l.With("foo", "bar").Warn("warn")
+	require.Contains(t, buf.String(), "\x1b[1;38;5;214mWARN\x1b[0m warn")
+
+	// The renderers of loggers without the theme are left as is.
+	require.NotSame(t, l.re, New(&buf).re)
+}
+
+func TestWithANSI256Theme_setOutput(t *testing.T) {
+	var a, b bytes.Buffer
+	l := New(&a, WithANSI256Theme())
+	l.SetOutput(&b)
+	require.Same(t, ansi256Renderer(&b), l.re)
+}
+
+func TestANSI256Profile(t *testing.T) {
+	require.Equal(t, termenv.ANSI256, ansi256Profile(termenv.TrueColor))
+	require.Equal(t, termenv.ANSI256, ansi256Profile(termenv.ANSI256))
+	require.Equal(t, termenv.ANSI256, ansi256Profile(termenv.ANSI))
+	require.Equal(t, termenv.Ascii, ansi256Profile(termenv.Ascii))
+}
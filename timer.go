@@ -0,0 +1,64 @@
+package log
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimerNameKey is the key of the field holding the name of the timers started
+// with StartTimer.
+var TimerNameKey = "name"
+
+// timer is a timer started with StartTimer.
+type timer struct {
+	name  string
+	start time.Time
+}
+
+// StartTimer starts the timer name, and returns a function that stops it. The
+// stop function logs the time elapsed since the timer started at debug level,
+// like "timer name=foo elapsed=123ms". Only its first call logs. Timers are
+// measured with the logger time function, and any number of them, with the
+// same name or not, can run concurrently.
+//
+//	defer logger.StartTimer("query")()
+func (l *Logger) StartTimer(name string) func() {
+	l.mu.Lock()
+	if l.timers == nil {
+		l.timers = &sync.Map{}
+	}
+	timers := l.timers
+	t := &timer{name: name, start: l.timeFunc()}
+	l.mu.Unlock()
+	timers.Store(t, struct{}{})
+
+	return func() {
+		if _, ok := timers.LoadAndDelete(t); !ok {
+			return
+		}
+		l.mu.RLock()
+		now := l.timeFunc()
+		l.mu.RUnlock()
+		l.logDepth(1, DebugLevel, "timer", TimerNameKey, name, ElapsedKey, roundDuration(now.Sub(t.start)))
+	}
+}
+
+// ActiveTimers returns the sorted names of the timers of the logger that are
+// still running. Loggers derived from it have their own timers.
+func (l *Logger) ActiveTimers() []string {
+	l.mu.RLock()
+	timers := l.timers
+	l.mu.RUnlock()
+	if timers == nil {
+		return nil
+	}
+
+	var names []string
+	timers.Range(func(k, _ interface{}) bool {
+		names = append(names, k.(*timer).name)
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTimer(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	l := NewWithOptions(&buf, Options{
+		Level:        DebugLevel,
+		ReportCaller: true,
+		TimeFunction: func() time.Time { return now },
+	})
+
+	stop := l.StartTimer("foo")
+	other := l.StartTimer("bar")
+	require.Equal(t, []string{"bar", "foo"}, l.ActiveTimers())
+	require.Empty(t, l.With().ActiveTimers())
+
+	now = now.Add(123 * time.Millisecond)
+	_, _, line, _ := runtime.Caller(0)
+	stop()
+	stop()
+	require.Equal(t, fmt.Sprintf("DEBU <log/timer_test.go:%d> timer name=foo elapsed=123ms\n", line+1), buf.String())
+	require.Equal(t, []string{"bar"}, l.ActiveTimers())
+
+	other()
+	require.Empty(t, l.ActiveTimers())
+}
+
+func TestStartTimer_level(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.StartTimer("foo")()
+	require.Empty(t, buf.String())
+	require.Empty(t, l.ActiveTimers())
+}
+
+func TestStartTimer_concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	l.SetLevel(DebugLevel)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.StartTimer("foo")()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 10, bytes.Count(buf.Bytes(), []byte("timer name=foo")))
+	require.Empty(t, l.ActiveTimers())
+}
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// TUILogger is a Logger that cooperates with terminal user interfaces, like
+// progress bars, that would otherwise be corrupted by log output. It
+// re-renders the interface after each entry, and can hold entries back while
+// the interface is drawing.
+type TUILogger struct {
+	*Logger
+	w *tuiWriter
+}
+
+// NewTUILogger returns a new TUILogger that writes to the output of l, and
+// calls render after each entry to re-render the interface. Loggers derived
+// from the returned logger also call render.
+func NewTUILogger(l *Logger, render func()) *TUILogger {
+	l.mu.RLock()
+	tw := &tuiWriter{w: l.w, render: render}
+	l.mu.RUnlock()
+
+	sl := l.With()
+	sl.w = tw
+	return &TUILogger{Logger: sl, w: tw}
+}
+
+// Suspend holds entries back in a buffer, instead of writing them to the
+// terminal, until Resume is called.
+func (t *TUILogger) Suspend() {
+	t.w.mu.Lock()
+	defer t.w.mu.Unlock()
+	t.w.suspended = true
+}
+
+// Resume writes the entries held back since Suspend was called, re-renders
+// the interface, and writes the next entries directly.
+func (t *TUILogger) Resume() error {
+	t.w.mu.Lock()
+	defer t.w.mu.Unlock()
+	if !t.w.suspended {
+		return nil
+	}
+	t.w.suspended = false
+	if len(t.w.held) == 0 {
+		return nil
+	}
+	var err error
+	for _, e := range t.w.held {
+		if _, werr := writeLevel(t.w.w, e.level, e.p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	t.w.held = nil
+	if t.w.render != nil {
+		t.w.render()
+	}
+	return err
+}
+
+// tuiWriter writes entries to w, or holds them back while suspended, and
+// calls render after each write to w.
+type tuiWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	render    func()
+	suspended bool
+	held      []heldEntry
+}
+
+// heldEntry is an entry held back by a suspended tuiWriter.
+type heldEntry struct {
+	level Level
+	p     []byte
+}
+
+// Write implements io.Writer.
+func (w *tuiWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(noLevel, p)
+}
+
+// WriteLevel writes p, an entry at level, to the underlying writer and
+// re-renders the interface, or holds p back while suspended.
+func (w *tuiWriter) WriteLevel(level Level, p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.suspended {
+		w.held = append(w.held, heldEntry{level: level, p: append([]byte(nil), p...)})
+		return len(p), nil
+	}
+	n, err := writeLevel(w.w, level, p)
+	if w.render != nil {
+		w.render()
+	}
+	return n, err
+}
+
+// Unwrap returns the underlying writer.
+func (w *tuiWriter) Unwrap() io.Writer {
+	return w.w
+}
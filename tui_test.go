@@ -0,0 +1,62 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUILogger(t *testing.T) {
+	var buf bytes.Buffer
+	renders := 0
+	l := NewTUILogger(New(&buf), func() {
+		renders++
+		buf.WriteString("[bar]\n")
+	})
+
+	l.Info("hello")
+	require.Equal(t, "INFO hello\n[bar]\n", buf.String())
+	require.Equal(t, 1, renders)
+
+	buf.Reset()
+	l.Suspend()
+	l.Info("one")
+	l.With("foo", "bar").Warn("two")
+	require.Empty(t, buf.String())
+	require.Equal(t, 1, renders)
+
+	require.NoError(t, l.Resume())
+	require.Equal(t, "INFO one\nWARN two foo=bar\n[bar]\n", buf.String())
+	require.Equal(t, 2, renders)
+
+	buf.Reset()
+	require.NoError(t, l.Resume())
+	l.Error("three")
+	require.Equal(t, "ERRO three\n[bar]\n", buf.String())
+	require.Equal(t, 3, renders)
+}
+
+func TestTUILogger_nilRender(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewTUILogger(New(&buf), nil)
+	l.Suspend()
+	l.Info("hello")
+	require.NoError(t, l.Resume())
+	require.Equal(t, "INFO hello\n", buf.String())
+}
+
+func TestTUILogger_close(t *testing.T) {
+	var buf closeBuffer
+	bw := bufio.NewWriter(&buf)
+	l := NewTUILogger(New(bw), nil)
+	l.Info("hello")
+	require.Empty(t, buf.String())
+	require.NoError(t, l.Flush())
+	require.Equal(t, "INFO hello\n", buf.String())
+
+	l = NewTUILogger(New(&buf), nil)
+	require.NoError(t, l.Close())
+	require.True(t, buf.closed)
+}
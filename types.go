@@ -0,0 +1,29 @@
+package log
+
+import "fmt"
+
+// WithTypeAnnotations returns a logger option that appends the Go type of
+// each value to its key, in parentheses, like "count(int)=5
+// name(string)=alice". It's a debugging aid to catch unexpected types, like
+// numbers passed as strings, or values decoded with reflection. It applies to
+// the logger fields and the key-value pairs of the entries, not to the fields
+// added by the logger itself, like the elapsed time.
+func WithTypeAnnotations() LoggerOption {
+	return func(l *Logger) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.typeAnnotations = true
+	}
+}
+
+// annotateTypes appends the type of each value of keyvals to its key, in
+// place.
+func annotateTypes(keyvals []interface{}) {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		typ := "nil"
+		if v := keyvals[i+1]; v != nil {
+			typ = fmt.Sprintf("%T", v)
+		}
+		keyvals[i] = fmt.Sprint(keyvals[i]) + "(" + typ + ")"
+	}
+}
@@ -0,0 +1,55 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTypeAnnotations(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyvals  []interface{}
+		expected string
+	}{
+		{
+			name:     "basic types",
+			keyvals:  []interface{}{"count", 5, "name", "alice", "ok", true, "ratio", 0.5},
+			expected: "INFO hello app(string)=api count(int)=5 name(string)=alice ok(bool)=true ratio(float64)=0.5\n",
+		},
+		{
+			name:     "string number",
+			keyvals:  []interface{}{"count", "5"},
+			expected: "INFO hello app(string)=api count(string)=5\n",
+		},
+		{
+			name:     "nil and error",
+			keyvals:  []interface{}{"err", errors.New("boom"), "val", nil},
+			expected: "INFO hello app(string)=api err(*errors.errorString)=boom val(nil)=<nil>\n",
+		},
+		{
+			name:     "duration",
+			keyvals:  []interface{}{"took", time.Second},
+			expected: "INFO hello app(string)=api took(time.Duration)=1s\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, WithTypeAnnotations()).With("app", "api")
+			l.Info("hello", c.keyvals...)
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
+
+func TestWithTypeAnnotations_loggerFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, WithTypeAnnotations()).With("id", 1)
+	l.Info("one")
+	l.Info("two")
+	require.Equal(t, "INFO one id(int)=1\nINFO two id(int)=1\n", buf.String())
+}
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"io"
+	"os"
+)
+
+// flusher is implemented by writers that buffer their output, like
+// bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Rotator is implemented by writers that support log rotation.
+type Rotator interface {
+	Rotate() error
+}
+
+// unwrapper is implemented by writers that wrap another writer.
+type unwrapper interface {
+	Unwrap() io.Writer
+}
+
+// levelWriter is implemented by writers that need the level of the entries
+// written to them.
+type levelWriter interface {
+	WriteLevel(level Level, p []byte) (int, error)
+}
+
+// writeLevel writes p, an entry at level, to w, passing the level along if w
+// needs it.
+func writeLevel(w io.Writer, level Level, p []byte) (int, error) {
+	if lw, ok := w.(levelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+	return w.Write(p)
+}
+
+// findWriter walks the chain of wrapped writers, starting with w, and returns
+// the first writer for which match returns true.
+func findWriter(w io.Writer, match func(io.Writer) bool) io.Writer {
+	for w != nil {
+		if match(w) {
+			return w
+		}
+		u, ok := w.(unwrapper)
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+	return nil
+}
+
+// flushWriter flushes the writer if it supports flushing.
+func flushWriter(w io.Writer) error {
+	f := findWriter(w, func(w io.Writer) bool {
+		_, ok := w.(flusher)
+		return ok
+	})
+	if f != nil {
+		return f.(flusher).Flush()
+	}
+	return nil
+}
+
+// closeWriter closes the writer if it supports closing. The standard output
+// and error streams are never closed.
+func closeWriter(w io.Writer) error {
+	c := findWriter(w, func(w io.Writer) bool {
+		_, ok := w.(io.Closer)
+		return ok || w == os.Stdout || w == os.Stderr
+	})
+	if c == nil || c == os.Stdout || c == os.Stderr {
+		return nil
+	}
+	return c.(io.Closer).Close()
+}
+
+// rotateWriter rotates the writer if it supports rotation.
+func rotateWriter(w io.Writer) error {
+	r := findWriter(w, func(w io.Writer) bool {
+		_, ok := w.(Rotator)
+		return ok
+	})
+	if r != nil {
+		return r.(Rotator).Rotate()
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type rotateBuffer struct {
+	bytes.Buffer
+	rotated []string
+}
+
+func (b *rotateBuffer) Rotate() error {
+	b.rotated = append(b.rotated, b.String())
+	b.Reset()
+	return nil
+}
+
+func TestRotate(t *testing.T) {
+	var buf rotateBuffer
+	l := New(&buf)
+	l.Info("info")
+	require.NoError(t, l.Rotate())
+	l.Info("info")
+	require.Equal(t, []string{"INFO info\n"}, buf.rotated)
+	require.Equal(t, "INFO info\n", buf.String())
+}
+
+func TestRotate_wrapped(t *testing.T) {
+	var buf rotateBuffer
+	l := New(&buf, WithRetryWriter(2, time.Millisecond)).WithCircuitBreaker(2, time.Second)
+	l.Info("info")
+	require.NoError(t, l.Rotate())
+	require.Equal(t, []string{"INFO info\n"}, buf.rotated)
+}
+
+func TestRotate_unsupported(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, New(&buf).Rotate())
+}
+
+func TestCloseWriter(t *testing.T) {
+	var buf closeBuffer
+	require.NoError(t, closeWriter(&retryWriter{w: &buf}))
+	require.True(t, buf.closed)
+	require.NoError(t, closeWriter(&retryWriter{w: os.Stderr}))
+}